@@ -0,0 +1 @@
+data "snyk_group_tags" "all" {}
@@ -0,0 +1,7 @@
+data "snyk_ignores" "example" {
+  organization = "ORG_ID_HERE"
+}
+
+output "expired_ignores" {
+  value = [for i in data.snyk_ignores.example.ignores : i if i.expired]
+}
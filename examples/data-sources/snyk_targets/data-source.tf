@@ -0,0 +1,4 @@
+data "snyk_targets" "example" {
+  organization = "ORG_ID_HERE"
+  display_name = "example-org/example-repo" # optional
+}
@@ -0,0 +1,15 @@
+ephemeral "snyk_oauth_token" "example" {
+  client_id     = var.snyk_client_id
+  client_secret = var.snyk_client_secret
+}
+
+# The token is only available during the run, so it can only be passed to
+# write-only arguments, provider configuration or other ephemeral resources.
+resource "snyk_integration" "example" {
+  organization = snyk_organization.example.id
+  type         = "gitlab"
+  credentials {
+    url      = "https://gitlab.example.com"
+    token_wo = ephemeral.snyk_oauth_token.example.access_token
+  }
+}
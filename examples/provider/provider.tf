@@ -2,3 +2,11 @@ provider "snyk" {
   group_id = "GROUP_ID" # can also provide in env as SNYK_API_GROUP
   api_key  = "API_KEY"  # can also provide in env as SNYK_API_KEY
 }
+
+# Alternatively, authenticate as a service account with OAuth client credentials
+provider "snyk" {
+  alias         = "oauth"
+  group_id      = "GROUP_ID"
+  client_id     = "CLIENT_ID"     # can also provide in env as SNYK_CLIENT_ID
+  client_secret = "CLIENT_SECRET" # can also provide in env as SNYK_CLIENT_SECRET
+}
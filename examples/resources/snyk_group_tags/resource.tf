@@ -0,0 +1,13 @@
+resource "snyk_group_tags" "catalogue" {
+  delete_unused = true
+
+  tag {
+    key   = "team"
+    value = "platform"
+  }
+
+  tag {
+    key   = "team"
+    value = "payments"
+  }
+}
@@ -0,0 +1,3 @@
+# Ignores are imported using the organization, project and issue IDs, optionally followed by the ignored path
+terraform import snyk_ignore.example ORG_ID/PROJECT_ID/ISSUE_ID
+terraform import snyk_ignore.example "ORG_ID/PROJECT_ID/ISSUE_ID/lodash@4.17.11 > minimist@1.2.0"
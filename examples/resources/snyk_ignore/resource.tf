@@ -0,0 +1,8 @@
+resource "snyk_ignore" "example" {
+  organization = snyk_organization.example.id
+  project      = "PROJECT_ID_HERE"
+  issue_id     = "SNYK-JS-LODASH-567746"
+  reason       = "Not reachable from user input, see SEC-123"
+  reason_type  = "temporary-ignore"
+  expires      = "2025-01-01T00:00:00Z"
+}
@@ -5,4 +5,16 @@ resource "snyk_integration" "example_integration" {
     username = "username"
     password = "password" # Make sure your backend is encrypted - this is stored in plaintext!
   }
-}
\ No newline at end of file
+}
+
+# With Terraform >= 1.11, write-only credentials are sent to Snyk but never stored in state.
+# Bump credentials_version to send them again after they change.
+resource "snyk_integration" "example_write_only" {
+  organization        = snyk_organization.example.id
+  type                = "gitlab"
+  credentials_version = 1
+  credentials {
+    url      = "https://gitlab.example.com"
+    token_wo = ephemeral.vault_kv_secret_v2.gitlab.data["token"]
+  }
+}
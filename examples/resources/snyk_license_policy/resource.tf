@@ -0,0 +1,16 @@
+resource "snyk_license_policy" "example" {
+  name          = "Copyleft"
+  description   = "Blocks strong copyleft licenses"
+  organizations = [snyk_organization.example.id]
+
+  license {
+    spdx_id  = "AGPL-3.0-only"
+    severity = "high"
+  }
+
+  license {
+    spdx_id      = "LGPL-2.1-only"
+    severity     = "medium"
+    instructions = "Dynamic linking only"
+  }
+}
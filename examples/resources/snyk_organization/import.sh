@@ -0,0 +1,3 @@
+# Organizations are imported by ID, or as GROUP_ID/ORG_ID when they're outside the provider's group
+terraform import snyk_organization.example ORG_ID
+terraform import snyk_organization.sandbox GROUP_ID/ORG_ID
@@ -1,3 +1,8 @@
 resource "snyk_organization" "example" {
   name = "Example Org"
 }
+
+resource "snyk_organization" "sandbox" {
+  name     = "Sandbox Org"
+  group_id = "SANDBOX_GROUP_ID_HERE"
+}
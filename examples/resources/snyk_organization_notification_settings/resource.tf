@@ -0,0 +1,6 @@
+resource "snyk_organization_notification_settings" "example" {
+  organization          = snyk_organization.example.id
+  new_issues_severity   = "high"
+  new_issues_type       = "vuln"
+  weekly_report_enabled = false
+}
@@ -0,0 +1,7 @@
+resource "snyk_organization_settings" "example" {
+  organization = snyk_organization.example.id
+
+  request_access {
+    enabled = true
+  }
+}
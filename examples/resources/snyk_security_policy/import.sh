@@ -0,0 +1,3 @@
+# Policies are imported by ID, or as GROUP_ID/POLICY_ID when they're outside the provider's group
+terraform import snyk_security_policy.example POLICY_ID
+terraform import snyk_security_policy.sandbox GROUP_ID/POLICY_ID
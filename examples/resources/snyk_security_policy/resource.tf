@@ -0,0 +1,33 @@
+resource "snyk_security_policy" "example" {
+  name = "External services"
+
+  project_attributes {
+    environment = ["external"]
+    lifecycle   = ["production"]
+  }
+
+  rule {
+    name     = "Raise mature exploits"
+    action   = "change_severity"
+    severity = "critical"
+
+    condition {
+      field    = "exploit_maturity"
+      operator = "includes"
+      value    = "mature"
+    }
+  }
+
+  rule {
+    name        = "Ignore low scores"
+    action      = "ignore"
+    ignore_type = "wont-fix"
+    reason      = "Below risk threshold"
+
+    condition {
+      field    = "cvss_score"
+      operator = "lte"
+      value    = "3"
+    }
+  }
+}
@@ -0,0 +1,22 @@
+resource "snyk_service_account" "ci" {
+  name    = "ci-pipeline"
+  role_id = "ROLE_ID_HERE"
+
+  # change any value to rotate the token
+  rotation_trigger = {
+    rotated = "2024-01-01"
+  }
+}
+
+resource "snyk_service_account" "other_group" {
+  group_id = "OTHER_GROUP_ID"
+  name     = "ci-pipeline"
+  role_id  = "ROLE_ID_HERE"
+}
+
+resource "snyk_service_account" "org_scoped" {
+  organization = snyk_organization.example.id
+  name         = "org-scanner"
+  role_id      = "ROLE_ID_HERE"
+  auth_type    = "oauth_client_secret"
+}
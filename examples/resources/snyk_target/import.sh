@@ -0,0 +1,2 @@
+# Targets are imported using the organization ID and target ID
+terraform import snyk_target.example ORG_ID/TARGET_ID
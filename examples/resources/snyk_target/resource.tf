@@ -0,0 +1,7 @@
+resource "snyk_target" "example" {
+  organization   = snyk_organization.example.id
+  integration_id = "INTEGRATION_ID_HERE"
+  owner          = "example-org"
+  name           = "example-repo"
+  branch         = "main"
+}
@@ -0,0 +1,2 @@
+# Webhooks are imported using the organization ID and webhook ID
+terraform import snyk_webhook.alerting ORG_ID/WEBHOOK_ID
@@ -0,0 +1,5 @@
+resource "snyk_webhook" "alerting" {
+  organization = snyk_organization.example.id
+  url          = "https://alerts.example.com/snyk"
+  secret       = var.webhook_secret
+}
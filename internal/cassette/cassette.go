@@ -0,0 +1,278 @@
+// Package cassette records HTTP interactions to a file and replays them, so that API client tests can
+// run deterministically without network access or credentials.
+//
+// Recording goes through to the real server and saves each request and response; replaying serves
+// responses from the file, matching requests by method, path, query and body. Authorization headers are
+// never saved, and the Scrub and Replace options keep other secrets out of the files.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type Mode int
+
+const (
+	ModeReplay Mode = iota
+	ModeRecord
+)
+
+// ModeFromEnv records when SNYK_RECORD_CASSETTES is set, and replays otherwise.
+func ModeFromEnv() Mode {
+	if os.Getenv("SNYK_RECORD_CASSETTES") != "" {
+		return ModeRecord
+	}
+
+	return ModeReplay
+}
+
+type Request struct {
+	Method string `json:"method"`
+	Url    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Options adjust what is saved. Replace substitutes real values (such as a group ID) with placeholders when
+// saving, so tests replay with the placeholders; Scrub rewrites bodies, for example to redact credentials.
+type Options struct {
+	Replace map[string]string
+	Scrub   func(body []byte) []byte
+}
+
+// savedHeaders are the response headers worth keeping.
+var savedHeaders = []string{"Content-Type", "Location", "Retry-After", "X-RateLimit-Remaining", "X-RateLimit-Reset"}
+
+// Recorder is an http.RoundTripper that records or replays a cassette.
+type Recorder struct {
+	path    string
+	mode    Mode
+	options Options
+	real    http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New loads the cassette at path (with a .json extension added) to replay, or starts a new one to record.
+func New(path string, mode Mode, options Options) (*Recorder, error) {
+	r := &Recorder{
+		path:    path + ".json",
+		mode:    mode,
+		options: options,
+		real:    http.DefaultTransport,
+	}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(r.path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", r.path, err)
+	}
+
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := Request{
+		Method: req.Method,
+		Url:    r.scrubString(req.URL.RequestURI()),
+		Body:   r.scrubBody(body),
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, recorded)
+	}
+
+	return r.replay(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	res, err := r.real.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{}
+	for _, header := range savedHeaders {
+		if value := res.Header.Get(header); value != "" {
+			headers[header] = r.scrubString(value)
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			Status:  res.StatusCode,
+			Headers: headers,
+			Body:    r.scrubBody(body),
+		},
+	})
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// replay serves the first unused interaction matching the request.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+
+		r.used[i] = true
+
+		header := http.Header{}
+		for key, value := range interaction.Response.Headers {
+			header.Set(key, value)
+		}
+
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode: interaction.Response.Status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			Request:    req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s has no unused interaction for %s %s", r.path, recorded.Method, recorded.Url)
+}
+
+// Unused returns the interactions that haven't been replayed, so tests can check nothing was skipped. There
+// are none when recording.
+func (r *Recorder) Unused() []Interaction {
+	if r.mode == ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
+
+// Stop saves the cassette when recording.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+func (r *Recorder) scrubString(s string) string {
+	for real, placeholder := range r.options.Replace {
+		if real != "" {
+			s = strings.ReplaceAll(s, real, placeholder)
+		}
+	}
+
+	return s
+}
+
+func (r *Recorder) scrubBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if r.options.Scrub != nil {
+		body = r.options.Scrub(body)
+	}
+
+	return r.scrubString(string(body))
+}
+
+// matches compares requests, treating bodies as equal if they hold the same JSON.
+func matches(recorded Request, actual Request) bool {
+	if recorded.Method != actual.Method || recorded.Url != actual.Url {
+		return false
+	}
+
+	if recorded.Body == actual.Body {
+		return true
+	}
+
+	var a, b interface{}
+	if json.Unmarshal([]byte(recorded.Body), &a) != nil || json.Unmarshal([]byte(actual.Body), &b) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,93 @@
+package cassette
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Write([]byte(`{"group":"real-group","token":"response-secret"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "example")
+	options := Options{
+		Replace: map[string]string{"real-group": "test-group"},
+		Scrub: func(body []byte) []byte {
+			return bytes.ReplaceAll(body, []byte("secret"), []byte("***"))
+		},
+	}
+
+	recorder, err := New(path, ModeRecord, options)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &http.Client{Transport: recorder}
+	req, _ := http.NewRequest("POST", server.URL+"/groups/real-group", strings.NewReader(`{"password": "secret"}`))
+	req.Header.Set("Authorization", "token real-api-key")
+
+	res, err := client.Do(req)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if len(recorder.Unused()) != 0 {
+		t.Error("expected no unused interactions when recording")
+	}
+
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, _ := os.ReadFile(path + ".json")
+
+	for _, secret := range []string{"real-api-key", "real-group", "secret", "session"} {
+		if bytes.Contains(saved, []byte(secret)) {
+			t.Errorf("expected %q to be scrubbed from the cassette:\n%s", secret, saved)
+		}
+	}
+
+	replayer, err := New(path, ModeReplay, options)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client = &http.Client{Transport: replayer}
+
+	// whitespace differences in JSON bodies don't matter
+	req, _ = http.NewRequest("POST", "https://api.snyk.io/groups/test-group", strings.NewReader(`{"password":"secret"}`))
+	res, err = client.Do(req)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if res.StatusCode != 200 || string(body) != `{"group":"test-group","token":"response-***"}` {
+		t.Errorf("unexpected replayed response: %d %s", res.StatusCode, body)
+	}
+
+	if len(replayer.Unused()) != 0 {
+		t.Error("expected every interaction to be replayed")
+	}
+
+	if _, err := client.Get("https://api.snyk.io/groups/test-group"); err == nil {
+		t.Error("expected an error for a request that wasn't recorded")
+	}
+}
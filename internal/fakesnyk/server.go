@@ -0,0 +1,1361 @@
+// Package fakesnyk is an in-memory stand-in for the parts of the Snyk API used by the provider, so that
+// acceptance tests can run without a Snyk account. It serves the v1 API under /v1 and the REST API under
+// /rest, like api.snyk.io.
+package fakesnyk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	GroupId = "00000000-0000-0000-0000-000000000000"
+	ApiKey  = "fake-snyk-api-key"
+
+	// OtherGroupId is a second group the credentials can manage, for resources that set their own group.
+	OtherGroupId = "11111111-1111-1111-1111-111111111111"
+
+	// ClientId and ClientSecret are the OAuth client credentials the fake exchanges for access tokens.
+	ClientId     = "fake-snyk-client-id"
+	ClientSecret = "fake-snyk-client-secret"
+)
+
+type Org struct {
+	Id      string
+	GroupId string
+	Name    string
+	Slug    string
+	Created time.Time
+}
+
+type Integration struct {
+	Id          string
+	Type        string
+	Credentials map[string]string
+}
+
+// Ignore is an ignore rule on an issue in a project. Snyk keeps a list of them per issue, one per path.
+type Ignore struct {
+	Path       string     `json:"ignorePath"`
+	Reason     string     `json:"reason,omitempty"`
+	ReasonType string     `json:"reasonType"`
+	Expires    *time.Time `json:"expires,omitempty"`
+	Created    time.Time  `json:"-"`
+}
+
+// Webhook is an organization's webhook. The fake never delivers events, so every ping succeeds.
+type Webhook struct {
+	Id     string `json:"id"`
+	Url    string `json:"url"`
+	Secret string `json:"-"`
+}
+
+// GroupTag is a project tag in a group. Snyk won't delete a tag that's in use without force.
+type GroupTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	InUse bool   `json:"-"`
+}
+
+// ServiceAccount is a group or organization service account. Its API key or client secret is only returned
+// when it's created or rotated.
+type ServiceAccount struct {
+	Id           string
+	Name         string
+	RoleId       string
+	AuthType     string
+	ClientId     string
+	ClientSecret string
+}
+
+// Target is a repository imported into an organization through an integration.
+type Target struct {
+	Id              string
+	DisplayName     string
+	IntegrationId   string
+	IntegrationType string
+	Created         time.Time
+}
+
+type Project struct {
+	Id     string
+	Name   string
+	Type   string
+	Origin string
+}
+
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	nextId       int
+	orgs         []*Org
+	integrations map[string]map[string]*Integration
+	projects     map[string][]*Project
+	ignores      map[string][]Ignore
+	webhooks     map[string][]*Webhook
+	groupTags    map[string][]GroupTag
+	accounts     map[string][]*ServiceAccount
+	settings     map[string]map[string]json.RawMessage
+	policies     map[string][]map[string]interface{}
+	targets      map[string][]*Target
+	importJobs   map[string]string
+	tokens       map[string]time.Time
+
+	// TokenLifetime is how long access tokens issued from then on are valid for.
+	TokenLifetime time.Duration
+}
+
+// NewServer starts a fake Snyk API for the group GroupId, accepting ApiKey or an access token issued for
+// ClientId and ClientSecret. Close it once finished.
+func NewServer() *Server {
+	s := &Server{
+		integrations:  map[string]map[string]*Integration{},
+		projects:      map[string][]*Project{},
+		ignores:       map[string][]Ignore{},
+		webhooks:      map[string][]*Webhook{},
+		groupTags:     map[string][]GroupTag{},
+		accounts:      map[string][]*ServiceAccount{},
+		settings:      map[string]map[string]json.RawMessage{},
+		policies:      map[string][]map[string]interface{}{},
+		targets:       map[string][]*Target{},
+		importJobs:    map[string]string{},
+		tokens:        map[string]time.Time{},
+		TokenLifetime: time.Hour,
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /v1/org", s.createOrg)
+	mux.HandleFunc("DELETE /v1/org/{org}", s.deleteOrg)
+	mux.HandleFunc("GET /v1/org/{org}/integrations", s.listIntegrations)
+	mux.HandleFunc("POST /v1/org/{org}/integrations", s.createIntegration)
+	mux.HandleFunc("GET /v1/org/{org}/integrations/{type}", s.getIntegration)
+	mux.HandleFunc("PUT /v1/org/{org}/integrations/{integration}", s.updateIntegration)
+	mux.HandleFunc("DELETE /v1/org/{org}/integrations/{integration}/authentication", s.deleteIntegration)
+
+	mux.HandleFunc("GET /v1/org/{org}/settings", s.getSettings)
+	mux.HandleFunc("PUT /v1/org/{org}/settings", s.updateSettings)
+	mux.HandleFunc("GET /v1/org/{org}/notification-settings", s.getSettings)
+	mux.HandleFunc("PUT /v1/org/{org}/notification-settings", s.updateSettings)
+
+	mux.HandleFunc("POST /v1/org/{org}/integrations/{integration}/import", s.importTarget)
+	mux.HandleFunc("GET /v1/org/{org}/integrations/{integration}/import/{job}", s.getImportJob)
+
+	mux.HandleFunc("GET /v1/org/{org}/project/{project}/ignores", s.listIgnores)
+	mux.HandleFunc("GET /v1/org/{org}/project/{project}/ignore/{issue}", s.getIgnores)
+	mux.HandleFunc("POST /v1/org/{org}/project/{project}/ignore/{issue}", s.createIgnore)
+	mux.HandleFunc("PUT /v1/org/{org}/project/{project}/ignore/{issue}", s.replaceIgnores)
+	mux.HandleFunc("DELETE /v1/org/{org}/project/{project}/ignore/{issue}", s.deleteIgnores)
+
+	mux.HandleFunc("GET /v1/org/{org}/webhooks", s.listWebhooks)
+	mux.HandleFunc("POST /v1/org/{org}/webhooks", s.createWebhook)
+	mux.HandleFunc("POST /v1/org/{org}/webhooks/{webhook}/ping", s.pingWebhook)
+	mux.HandleFunc("DELETE /v1/org/{org}/webhooks/{webhook}", s.deleteWebhook)
+
+	mux.HandleFunc("GET /v1/group/{group}/tags", s.listGroupTags)
+	mux.HandleFunc("POST /v1/group/{group}/tags/delete", s.deleteGroupTag)
+
+	mux.HandleFunc("POST /v1/group/{group}/policies", s.createPolicy)
+	mux.HandleFunc("GET /v1/group/{group}/policies/{policy}", s.getPolicy)
+	mux.HandleFunc("PUT /v1/group/{group}/policies/{policy}", s.updatePolicy)
+	mux.HandleFunc("DELETE /v1/group/{group}/policies/{policy}", s.deletePolicy)
+
+	mux.HandleFunc("GET /rest/groups/{group}/orgs", s.listOrgs)
+
+	for _, scope := range []string{"/rest/groups/{group}/service_accounts", "/rest/orgs/{org}/service_accounts"} {
+		mux.HandleFunc("POST "+scope, s.createServiceAccount)
+		mux.HandleFunc("GET "+scope+"/{account}", s.getServiceAccount)
+		mux.HandleFunc("PATCH "+scope+"/{account}", s.updateServiceAccount)
+		mux.HandleFunc("DELETE "+scope+"/{account}", s.deleteServiceAccount)
+		mux.HandleFunc("POST "+scope+"/{account}/secrets", s.rotateServiceAccountSecret)
+	}
+
+	mux.HandleFunc("GET /rest/orgs/{org}/projects", s.listProjects)
+	mux.HandleFunc("GET /rest/orgs/{org}/targets", s.listTargets)
+	mux.HandleFunc("GET /rest/orgs/{org}/targets/{target}", s.getTarget)
+	mux.HandleFunc("DELETE /rest/orgs/{org}/targets/{target}", s.deleteTarget)
+
+	root := http.NewServeMux()
+	root.HandleFunc("POST /oauth2/token", s.issueToken)
+	root.Handle("/", s.authenticate(mux))
+
+	s.Server = httptest.NewServer(root)
+
+	return s
+}
+
+// Org returns the organization with the given ID, or nil if there isn't one.
+func (s *Server) Org(id string) *Org {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.findOrg(id)
+}
+
+// Integration returns the integration of the given type in an organization, or nil if there isn't one.
+func (s *Server) Integration(orgId string, intType string) *Integration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.integrations[orgId][intType]
+}
+
+// AddProject adds a project to an organization, as importing a target would.
+func (s *Server) AddProject(orgId string, name string, projectType string, origin string) *Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := &Project{Id: s.newId(), Name: name, Type: projectType, Origin: origin}
+	s.projects[orgId] = append(s.projects[orgId], project)
+
+	return project
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+
+		if authorization != "token "+ApiKey && !s.validToken(strings.TrimPrefix(authorization, "Bearer ")) {
+			writeError(w, r, http.StatusUnauthorized, "invalid credentials")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RevokeTokens invalidates every access token issued so far, as if they'd expired.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]time.Time{}
+}
+
+// AddGroupTag adds a tag to a group, as applying it to a project would. Tags in use can only be deleted with
+// force.
+func (s *Server) AddGroupTag(groupId string, key string, value string, inUse bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.groupTags[groupId] = append(s.groupTags[groupId], GroupTag{Key: key, Value: value, InUse: inUse})
+}
+
+// GroupTags returns the tags in a group.
+func (s *Server) GroupTags(groupId string) []GroupTag {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]GroupTag{}, s.groupTags[groupId]...)
+}
+
+// ServiceAccount returns the service account with the given ID in a group, or nil if there isn't one.
+func (s *Server) ServiceAccount(groupId string, id string) *ServiceAccount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, account := range s.accounts["groups/"+groupId] {
+		if account.Id == id {
+			return account
+		}
+	}
+
+	return nil
+}
+
+// Ignores returns the ignore rules on an issue in a project.
+func (s *Server) Ignores(orgId string, projectId string, issueId string) []Ignore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Ignore(nil), s.ignores[ignoreKey(orgId, projectId, issueId)]...)
+}
+
+func (s *Server) validToken(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiry, ok := s.tokens[token]
+
+	return ok && time.Now().Before(expiry)
+}
+
+// issueToken implements the OAuth client credentials grant.
+func (s *Server) issueToken(w http.ResponseWriter, r *http.Request) {
+	if r.PostFormValue("grant_type") != "client_credentials" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type", "error_description": "only client_credentials is supported"})
+		return
+	}
+
+	if r.PostFormValue("client_id") != ClientId || r.PostFormValue("client_secret") != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client", "error_description": "client authentication failed"})
+		return
+	}
+
+	s.mu.Lock()
+	token := "fake-access-token-" + s.newId()
+	s.tokens[token] = time.Now().Add(s.TokenLifetime)
+	lifetime := s.TokenLifetime
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": token,
+		"token_type":   "bearer",
+		"expires_in":   int(lifetime.Seconds()),
+		"scope":        "org.read org.edit",
+	})
+}
+
+func (s *Server) createOrg(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name    string `json:"name"`
+		GroupId string `json:"groupId"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	if req.GroupId != GroupId && req.GroupId != OtherGroupId {
+		writeError(w, r, http.StatusForbidden, "not a member of group "+req.GroupId)
+		return
+	}
+
+	s.mu.Lock()
+	org := &Org{
+		Id:      s.newId(),
+		GroupId: req.GroupId,
+		Name:    req.Name,
+		Slug:    strings.ToLower(strings.ReplaceAll(req.Name, " ", "-")),
+		Created: time.Now().UTC().Truncate(time.Second),
+	}
+	s.orgs = append(s.orgs, org)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"id":      org.Id,
+		"name":    org.Name,
+		"slug":    org.Slug,
+		"url":     "https://app.snyk.io/org/" + org.Slug,
+		"created": org.Created,
+	})
+}
+
+func (s *Server) deleteOrg(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("org")
+	for i, org := range s.orgs {
+		if org.Id == id {
+			s.orgs = append(s.orgs[:i], s.orgs[i+1:]...)
+			delete(s.integrations, id)
+			delete(s.projects, id)
+			delete(s.webhooks, id)
+			delete(s.targets, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	writeError(w, r, http.StatusNotFound, "org not found")
+}
+
+func (s *Server) listOrgs(w http.ResponseWriter, r *http.Request) {
+	group := r.PathValue("group")
+	if group != GroupId && group != OtherGroupId {
+		writeError(w, r, http.StatusNotFound, "group not found")
+		return
+	}
+
+	s.mu.Lock()
+	resources := make([]resource, 0, len(s.orgs))
+	for _, org := range s.orgs {
+		if org.GroupId != group {
+			continue
+		}
+
+		resources = append(resources, org.resource())
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, resources)
+}
+
+func (org *Org) resource() resource {
+	return resource{
+		Id:   org.Id,
+		Type: "org",
+		Attributes: map[string]interface{}{
+			"name":       org.Name,
+			"slug":       org.Slug,
+			"group_id":   org.GroupId,
+			"created_at": org.Created,
+		},
+	}
+}
+
+func ignoreKey(orgId string, projectId string, issueId string) string {
+	return orgId + "/" + projectId + "/" + issueId
+}
+
+func (s *Server) requestIgnoreKey(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if s.findOrg(r.PathValue("org")) == nil {
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return "", false
+	}
+
+	return ignoreKey(r.PathValue("org"), r.PathValue("project"), r.PathValue("issue")), true
+}
+
+func (s *Server) getIgnores(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.requestIgnoreKey(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ignoreRules(s.ignores[key]))
+}
+
+// listIgnores returns the rules of every ignored issue in the project, keyed by issue.
+func (s *Server) listIgnores(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findOrg(r.PathValue("org")) == nil {
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return
+	}
+
+	prefix := ignoreKey(r.PathValue("org"), r.PathValue("project"), "")
+	listing := map[string]interface{}{}
+	for key, ignores := range s.ignores {
+		if issueId, ok := strings.CutPrefix(key, prefix); ok && len(ignores) > 0 {
+			listing[issueId] = ignoreRules(ignores)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, listing)
+}
+
+// ignoreRules formats an issue's ignores as Snyk does, one map per rule keyed by the ignored path.
+func ignoreRules(ignores []Ignore) []map[string]interface{} {
+	rules := []map[string]interface{}{}
+	for _, ignore := range ignores {
+		rules = append(rules, map[string]interface{}{
+			ignore.Path: map[string]interface{}{
+				"reason":     ignore.Reason,
+				"reasonType": ignore.ReasonType,
+				"created":    ignore.Created,
+				"expires":    ignore.Expires,
+				"ignoredBy":  map[string]string{"email": "fake@example.com"},
+			},
+		})
+	}
+
+	return rules
+}
+
+// createIgnore adds a rule for a path, alongside the issue's existing rules.
+func (s *Server) createIgnore(w http.ResponseWriter, r *http.Request) {
+	var ignore Ignore
+
+	if err := json.NewDecoder(r.Body).Decode(&ignore); err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.requestIgnoreKey(w, r)
+	if !ok {
+		return
+	}
+
+	ignore.Created = time.Now().UTC().Truncate(time.Millisecond)
+	s.ignores[key] = append(s.ignores[key], ignore)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// replaceIgnores replaces every rule on the issue with those in the request.
+func (s *Server) replaceIgnores(w http.ResponseWriter, r *http.Request) {
+	var ignores []Ignore
+
+	if err := json.NewDecoder(r.Body).Decode(&ignores); err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.requestIgnoreKey(w, r)
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	for i := range ignores {
+		ignores[i].Created = now
+	}
+	s.ignores[key] = ignores
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// deleteIgnores removes every rule on the issue.
+func (s *Server) deleteIgnores(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.requestIgnoreKey(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.ignores, key)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) listIntegrations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := r.PathValue("org")
+	if s.findOrg(org) == nil {
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return
+	}
+
+	listing := map[string]string{}
+	for intType, integration := range s.integrations[org] {
+		listing[intType] = integration.Id
+	}
+
+	writeJSON(w, http.StatusOK, listing)
+}
+
+func (s *Server) createIntegration(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Type        string            `json:"type"`
+		Credentials map[string]string `json:"credentials"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Type == "" {
+		writeError(w, r, http.StatusBadRequest, "type is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := r.PathValue("org")
+	if s.findOrg(org) == nil {
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return
+	}
+
+	if s.integrations[org] == nil {
+		s.integrations[org] = map[string]*Integration{}
+	}
+
+	integration := s.integrations[org][req.Type]
+	if integration == nil {
+		integration = &Integration{Id: s.newId(), Type: req.Type}
+		s.integrations[org][req.Type] = integration
+	}
+	integration.Credentials = req.Credentials
+
+	writeJSON(w, http.StatusOK, map[string]string{"id": integration.Id})
+}
+
+func (s *Server) getIntegration(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	integration := s.integrations[r.PathValue("org")][r.PathValue("type")]
+	if integration == nil {
+		writeError(w, r, http.StatusNotFound, "integration not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"id": integration.Id})
+}
+
+func (s *Server) updateIntegration(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Credentials map[string]string `json:"credentials"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	integration := s.findIntegration(r.PathValue("org"), r.PathValue("integration"))
+	if integration == nil {
+		writeError(w, r, http.StatusNotFound, "integration not found")
+		return
+	}
+	integration.Credentials = req.Credentials
+
+	writeJSON(w, http.StatusOK, map[string]string{"id": integration.Id})
+}
+
+// deleteIntegration removes the integration's credentials, which in the fake removes the integration.
+func (s *Server) deleteIntegration(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := r.PathValue("org")
+	integration := s.findIntegration(org, r.PathValue("integration"))
+	if integration == nil {
+		writeError(w, r, http.StatusNotFound, "integration not found")
+		return
+	}
+	delete(s.integrations[org], integration.Type)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := r.PathValue("org")
+	if s.findOrg(org) == nil {
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return
+	}
+
+	hooks := append([]*Webhook{}, s.webhooks[org]...)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results": hooks,
+		"total":   len(hooks),
+	})
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Url    string `json:"url"`
+		Secret string `json:"secret"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !strings.HasPrefix(req.Url, "https://") || req.Secret == "" {
+		writeError(w, r, http.StatusBadRequest, "an https url and a secret are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := r.PathValue("org")
+	if s.findOrg(org) == nil {
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return
+	}
+
+	hook := &Webhook{Id: s.newId(), Url: req.Url, Secret: req.Secret}
+	s.webhooks[org] = append(s.webhooks[org], hook)
+
+	writeJSON(w, http.StatusOK, hook)
+}
+
+func (s *Server) pingWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findWebhook(r.PathValue("org"), r.PathValue("webhook")) < 0 {
+		writeError(w, r, http.StatusNotFound, "webhook not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := r.PathValue("org")
+	i := s.findWebhook(org, r.PathValue("webhook"))
+	if i < 0 {
+		writeError(w, r, http.StatusNotFound, "webhook not found")
+		return
+	}
+
+	s.webhooks[org] = append(s.webhooks[org][:i], s.webhooks[org][i+1:]...)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) listGroupTags(w http.ResponseWriter, r *http.Request) {
+	group := r.PathValue("group")
+	if group != GroupId && group != OtherGroupId {
+		writeError(w, r, http.StatusNotFound, "group not found")
+		return
+	}
+
+	perPage, err := strconv.Atoi(r.URL.Query().Get("perPage"))
+	if err != nil || perPage <= 0 {
+		perPage = 1000
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	s.mu.Lock()
+	tags := s.groupTags[group]
+	start := min((page-1)*perPage, len(tags))
+	end := min(start+perPage, len(tags))
+	listing := append([]GroupTag{}, tags[start:end]...)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tags": listing})
+}
+
+func (s *Server) deleteGroupTag(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+		Force bool   `json:"force"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group := r.PathValue("group")
+	for i, tag := range s.groupTags[group] {
+		if tag.Key != req.Key || tag.Value != req.Value {
+			continue
+		}
+
+		if tag.InUse && !req.Force {
+			writeError(w, r, http.StatusBadRequest, "tag is applied to projects, delete it with force")
+			return
+		}
+
+		s.groupTags[group] = append(s.groupTags[group][:i], s.groupTags[group][i+1:]...)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	writeError(w, r, http.StatusNotFound, "tag not found")
+}
+
+// getSettings serves both organization and notification settings, kept by path. They start empty and return
+// whichever sections have been set.
+func (s *Server) getSettings(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findOrg(r.PathValue("org")) == nil {
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return
+	}
+
+	settings := s.settings[r.URL.Path]
+	if settings == nil {
+		settings = map[string]json.RawMessage{}
+	}
+
+	writeJSON(w, http.StatusOK, settings)
+}
+
+// updateSettings replaces the sections in the request, keeping the others.
+func (s *Server) updateSettings(w http.ResponseWriter, r *http.Request) {
+	var sections map[string]json.RawMessage
+
+	if err := json.NewDecoder(r.Body).Decode(&sections); err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findOrg(r.PathValue("org")) == nil {
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return
+	}
+
+	if s.settings[r.URL.Path] == nil {
+		s.settings[r.URL.Path] = map[string]json.RawMessage{}
+	}
+
+	for section, value := range sections {
+		s.settings[r.URL.Path][section] = value
+	}
+
+	writeJSON(w, http.StatusOK, s.settings[r.URL.Path])
+}
+
+// importTarget imports a repository straight away, returning a job that has already completed.
+func (s *Server) importTarget(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Target struct {
+			Owner string `json:"owner"`
+			Name  string `json:"name"`
+		} `json:"target"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Target.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "target name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := r.PathValue("org")
+	if s.findOrg(org) == nil {
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return
+	}
+
+	integration := s.findIntegration(org, r.PathValue("integration"))
+	if integration == nil {
+		writeError(w, r, http.StatusNotFound, "integration not found")
+		return
+	}
+
+	target := &Target{
+		Id:              s.newId(),
+		DisplayName:     strings.TrimPrefix(req.Target.Owner+"/"+req.Target.Name, "/"),
+		IntegrationId:   integration.Id,
+		IntegrationType: integration.Type,
+		Created:         time.Now().UTC().Truncate(time.Second),
+	}
+	s.targets[org] = append(s.targets[org], target)
+
+	job := s.newId()
+	s.importJobs[job] = "complete"
+
+	w.Header().Set("Location", fmt.Sprintf("%s/api/v1/org/%s/integrations/%s/import/%s", s.URL, org, integration.Id, job))
+	writeJSON(w, http.StatusCreated, map[string]interface{}{})
+}
+
+func (s *Server) getImportJob(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	status, ok := s.importJobs[r.PathValue("job")]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "import job not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"id": r.PathValue("job"), "status": status})
+}
+
+func (s *Server) listTargets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	org := r.PathValue("org")
+	if s.findOrg(org) == nil {
+		s.mu.Unlock()
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return
+	}
+
+	displayName := r.URL.Query().Get("display_name")
+	resources := make([]resource, 0, len(s.targets[org]))
+	for _, target := range s.targets[org] {
+		if displayName == "" || target.DisplayName == displayName {
+			resources = append(resources, target.resource())
+		}
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, resources)
+}
+
+func (s *Server) getTarget(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, target := range s.targets[r.PathValue("org")] {
+		if target.Id == r.PathValue("target") {
+			writeResource(w, http.StatusOK, target.resource())
+			return
+		}
+	}
+
+	writeError(w, r, http.StatusNotFound, "target not found")
+}
+
+func (s *Server) deleteTarget(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := r.PathValue("org")
+	for i, target := range s.targets[org] {
+		if target.Id == r.PathValue("target") {
+			s.targets[org] = append(s.targets[org][:i], s.targets[org][i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	writeError(w, r, http.StatusNotFound, "target not found")
+}
+
+func (target *Target) resource() resource {
+	return resource{
+		Id:   target.Id,
+		Type: "target",
+		Attributes: map[string]interface{}{
+			"display_name": target.DisplayName,
+			"url":          "https://github.com/" + target.DisplayName,
+			"is_private":   false,
+			"created_at":   target.Created,
+		},
+		Relationships: map[string]interface{}{
+			"integration": map[string]interface{}{
+				"data": map[string]interface{}{
+					"id":         target.IntegrationId,
+					"type":       "integration",
+					"attributes": map[string]string{"integration_type": target.IntegrationType},
+				},
+			},
+		},
+	}
+}
+
+// createPolicy stores the policy as given, so that whatever the provider sends is returned unchanged.
+func (s *Server) createPolicy(w http.ResponseWriter, r *http.Request) {
+	var policy map[string]interface{}
+
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil || policy["name"] == nil || policy["type"] == nil {
+		writeError(w, r, http.StatusBadRequest, "name and type are required")
+		return
+	}
+
+	group := r.PathValue("group")
+	if group != GroupId && group != OtherGroupId {
+		writeError(w, r, http.StatusNotFound, "group not found")
+		return
+	}
+
+	s.mu.Lock()
+	policy["id"] = s.newId()
+	s.policies[group] = append(s.policies[group], policy)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, policy)
+}
+
+func (s *Server) findPolicy(r *http.Request) int {
+	for i, policy := range s.policies[r.PathValue("group")] {
+		if policy["id"] == r.PathValue("policy") {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) getPolicy(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findPolicy(r)
+	if i < 0 {
+		writeError(w, r, http.StatusNotFound, "policy not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.policies[r.PathValue("group")][i])
+}
+
+func (s *Server) updatePolicy(w http.ResponseWriter, r *http.Request) {
+	var policy map[string]interface{}
+
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findPolicy(r)
+	if i < 0 {
+		writeError(w, r, http.StatusNotFound, "policy not found")
+		return
+	}
+
+	policy["id"] = r.PathValue("policy")
+	s.policies[r.PathValue("group")][i] = policy
+
+	writeJSON(w, http.StatusOK, policy)
+}
+
+func (s *Server) deletePolicy(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group := r.PathValue("group")
+	i := s.findPolicy(r)
+	if i < 0 {
+		writeError(w, r, http.StatusNotFound, "policy not found")
+		return
+	}
+
+	s.policies[group] = append(s.policies[group][:i], s.policies[group][i+1:]...)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serviceAccountScope returns the key of the group or organization the request's service accounts are in.
+func (s *Server) serviceAccountScope(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if group := r.PathValue("group"); group != "" {
+		if group != GroupId && group != OtherGroupId {
+			writeError(w, r, http.StatusNotFound, "group not found")
+			return "", false
+		}
+
+		return "groups/" + group, true
+	}
+
+	if s.findOrg(r.PathValue("org")) == nil {
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return "", false
+	}
+
+	return "orgs/" + r.PathValue("org"), true
+}
+
+func (s *Server) findServiceAccount(w http.ResponseWriter, r *http.Request) (*ServiceAccount, bool) {
+	scope, ok := s.serviceAccountScope(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	for _, account := range s.accounts[scope] {
+		if account.Id == r.PathValue("account") {
+			return account, true
+		}
+	}
+
+	writeError(w, r, http.StatusNotFound, "service account not found")
+	return nil, false
+}
+
+func (s *Server) createServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Data struct {
+			Attributes struct {
+				Name     string `json:"name"`
+				RoleId   string `json:"role_id"`
+				AuthType string `json:"auth_type"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Data.Attributes.Name == "" || req.Data.Attributes.RoleId == "" {
+		writeError(w, r, http.StatusBadRequest, "name and role_id are required")
+		return
+	}
+
+	attributes := req.Data.Attributes
+	if attributes.AuthType != "api_key" && attributes.AuthType != "oauth_client_secret" {
+		writeError(w, r, http.StatusBadRequest, "unsupported auth_type "+attributes.AuthType)
+		return
+	}
+
+	s.mu.Lock()
+	scope, ok := s.serviceAccountScope(w, r)
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+
+	account := &ServiceAccount{
+		Id:       s.newId(),
+		Name:     attributes.Name,
+		RoleId:   attributes.RoleId,
+		AuthType: attributes.AuthType,
+	}
+	if account.AuthType == "oauth_client_secret" {
+		account.ClientId = "fake-client-" + account.Id
+	}
+	account.ClientSecret = "fake-secret-" + s.newId()
+	s.accounts[scope] = append(s.accounts[scope], account)
+	res := account.resource(true)
+	s.mu.Unlock()
+
+	writeResource(w, http.StatusCreated, res)
+}
+
+func (s *Server) getServiceAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	account, ok := s.findServiceAccount(w, r)
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	res := account.resource(false)
+	s.mu.Unlock()
+
+	writeResource(w, http.StatusOK, res)
+}
+
+func (s *Server) updateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Data struct {
+			Attributes struct {
+				Name string `json:"name"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Data.Attributes.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	s.mu.Lock()
+	account, ok := s.findServiceAccount(w, r)
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	account.Name = req.Data.Attributes.Name
+	res := account.resource(false)
+	s.mu.Unlock()
+
+	writeResource(w, http.StatusOK, res)
+}
+
+func (s *Server) deleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scope, ok := s.serviceAccountScope(w, r)
+	if !ok {
+		return
+	}
+
+	for i, account := range s.accounts[scope] {
+		if account.Id == r.PathValue("account") {
+			s.accounts[scope] = append(s.accounts[scope][:i], s.accounts[scope][i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	writeError(w, r, http.StatusNotFound, "service account not found")
+}
+
+func (s *Server) rotateServiceAccountSecret(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	account, ok := s.findServiceAccount(w, r)
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+
+	if account.AuthType != "oauth_client_secret" {
+		s.mu.Unlock()
+		writeError(w, r, http.StatusBadRequest, "only oauth_client_secret service accounts have secrets")
+		return
+	}
+
+	account.ClientSecret = "fake-secret-" + s.newId()
+	res := account.resource(true)
+	s.mu.Unlock()
+
+	writeResource(w, http.StatusOK, res)
+}
+
+// resource returns the service account as JSON:API, with its API key or client secret if it was just issued.
+func (account *ServiceAccount) resource(issued bool) resource {
+	attributes := map[string]interface{}{
+		"name":      account.Name,
+		"role_id":   account.RoleId,
+		"auth_type": account.AuthType,
+	}
+
+	if account.ClientId != "" {
+		attributes["client_id"] = account.ClientId
+	}
+
+	if issued && account.AuthType == "api_key" {
+		attributes["api_key"] = account.ClientSecret
+	} else if issued {
+		attributes["client_secret"] = account.ClientSecret
+	}
+
+	return resource{Id: account.Id, Type: "service_account", Attributes: attributes}
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	org := r.PathValue("org")
+	if s.findOrg(org) == nil {
+		s.mu.Unlock()
+		writeError(w, r, http.StatusNotFound, "org not found")
+		return
+	}
+
+	resources := make([]resource, 0, len(s.projects[org]))
+	for _, project := range s.projects[org] {
+		resources = append(resources, resource{
+			Id:   project.Id,
+			Type: "project",
+			Attributes: map[string]interface{}{
+				"name":   project.Name,
+				"type":   project.Type,
+				"origin": project.Origin,
+			},
+		})
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, resources)
+}
+
+func (s *Server) newId() string {
+	s.nextId++
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", s.nextId)
+}
+
+func (s *Server) findOrg(id string) *Org {
+	for _, org := range s.orgs {
+		if org.Id == id {
+			return org
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) findIntegration(org string, id string) *Integration {
+	for _, integration := range s.integrations[org] {
+		if integration.Id == id {
+			return integration
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) findWebhook(org string, id string) int {
+	for i, hook := range s.webhooks[org] {
+		if hook.Id == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type resource struct {
+	Id            string                 `json:"id"`
+	Type          string                 `json:"type"`
+	Attributes    map[string]interface{} `json:"attributes"`
+	Relationships map[string]interface{} `json:"relationships,omitempty"`
+}
+
+// writePage writes a JSON:API page of resources, paginated with the limit and starting_after parameters.
+func writePage(w http.ResponseWriter, r *http.Request, resources []resource) {
+	query := r.URL.Query()
+
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	start := 0
+	if after := query.Get("starting_after"); after != "" {
+		for i, res := range resources {
+			if res.Id == after {
+				start = i + 1
+			}
+		}
+	}
+
+	end := start + limit
+	if end > len(resources) {
+		end = len(resources)
+	}
+
+	page := resources[start:end]
+	links := map[string]string{}
+
+	if end < len(resources) {
+		query.Set("starting_after", page[len(page)-1].Id)
+		links["next"] = fmt.Sprintf("%s?%s", r.URL.Path, query.Encode())
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"jsonapi": map[string]string{"version": "1.0"},
+		"data":    page,
+		"links":   links,
+	})
+}
+
+// writeResource writes a single JSON:API resource.
+func writeResource(w http.ResponseWriter, status int, res resource) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	writeJSON(w, status, map[string]interface{}{
+		"jsonapi": map[string]string{"version": "1.0"},
+		"data":    res,
+	})
+}
+
+// writeError writes errors in the format of whichever API was called.
+func writeError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	if strings.HasPrefix(r.URL.Path, "/rest/") {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		writeJSON(w, status, map[string]interface{}{
+			"jsonapi": map[string]string{"version": "1.0"},
+			"errors": []map[string]string{{
+				"status": strconv.Itoa(status),
+				"title":  http.StatusText(status),
+				"detail": message,
+			}},
+		})
+		return
+	}
+
+	writeJSON(w, status, map[string]interface{}{
+		"code":    status,
+		"message": message,
+		"error":   message,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
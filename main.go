@@ -5,7 +5,7 @@ import (
 	"flag"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/lendi-au/terraform-provider-snyk/snyk"
 )
 
@@ -34,15 +34,24 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{ProviderFunc: snyk.Provider(version)}
+	shutdown, err := snyk.InitTracing(context.Background(), version)
+	if err != nil {
+		log.Printf("[WARN] tracing disabled: %s", err)
+	}
+	defer shutdown(context.Background())
+
+	serverFactory, err := snyk.ProviderServer(context.Background(), version)
+	if err != nil {
+		log.Fatal(err)
+	}
 
+	var opts []tf6server.ServeOpt
 	if debugMode {
-		err := plugin.Debug(context.Background(), "registry.terraform.io/lendi-au/snyk", opts)
-		if err != nil {
-			log.Fatal(err.Error())
-		}
-		return
+		opts = append(opts, tf6server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
+	err = tf6server.Serve("registry.terraform.io/lendi-au/snyk", serverFactory, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
@@ -2,15 +2,42 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// DefaultEndpoint is the Snyk API host, serving the v1 API under /v1 and the REST API under /rest.
+const DefaultEndpoint = "https://api.snyk.io"
+
 type SnykOptions struct {
 	GroupId   string
 	ApiKey    string
 	UserAgent string
+
+	// Tokens, if set, authenticates requests with OAuth access tokens in place of ApiKey.
+	Tokens *TokenSource
+
+	// Endpoint overrides DefaultEndpoint, for regional instances of Snyk or a fake API in tests.
+	Endpoint string
+
+	// Organizations caches the group's organization listing, shared by every copy of the options.
+	Organizations *OrganizationCache
+
+	// RateLimiter, if set, is waited on before every request.
+	RateLimiter *RateLimiter
+
+	// PreventOrganizationDeletion forbids deleting organizations. It's enforced by the snyk_organization
+	// resource rather than the API client.
+	PreventOrganizationDeletion bool
+
+	// HttpClient, if set, replaces the default client, such as to record or replay requests in tests.
+	HttpClient *http.Client
 }
 
 var ErrInvalidAuthn = errors.New("credentials not valid")
@@ -18,28 +45,110 @@ var ErrInvalidAuthz = errors.New("credentials not authorized to access resource"
 var ErrNotFound = errors.New("requested resource not found")
 var ErrUnexpectedStatus = errors.New("unexpected HTTP status code")
 
-func clientDo(so SnykOptions, method string, path string, body []byte) (*http.Response, error) {
-	client := &http.Client{}
-	req, _ := http.NewRequest(method, constructUrl(path), bytes.NewReader(body))
+func clientDo(ctx context.Context, so SnykOptions, method string, path string, body []byte) (*http.Response, error) {
+	req, _ := http.NewRequestWithContext(ctx, method, constructUrl(so, path), bytes.NewReader(body))
+
+	generateHeaders(so, req)
+
+	return doRequest(so, req, statusError)
+}
+
+// restClientDo calls the versioned Snyk REST API, which lives alongside the v1 API
+// on a separate host and requires every call to pin an API version date.
+func restClientDo(ctx context.Context, so SnykOptions, method string, path string, version string, body []byte) (*http.Response, error) {
+	req, _ := http.NewRequestWithContext(ctx, method, constructRestUrl(so, path, version), bytes.NewReader(body))
 
 	generateHeaders(so, req)
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+
+	return doRequest(so, req, jsonApiError)
+}
+
+// doRequest performs the request, converting any unsuccessful response into an error with errorFn. Requests
+// throttled by the server are retried once the server allows, and requests with an access token the server
+// rejects are retried once with a new token.
+func doRequest(so SnykOptions, req *http.Request, errorFn func(*http.Response) error) (*http.Response, error) {
+	client := so.HttpClient
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	ctx := requestLogger(so, req)
+
+	var token string
+	reauthenticated := false
+
+	for attempt := 0; ; attempt++ {
+		if so.RateLimiter != nil {
+			if err := so.RateLimiter.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
 
-	res, err := client.Do(req)
+		if so.Tokens != nil {
+			var err error
+			if token, err = so.Tokens.Token(ctx); err != nil {
+				return nil, err
+			}
 
-	if err != nil {
-		return nil, err
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
+
+		logRequest(ctx, req)
+		start := time.Now()
+
+		spanCtx, span := startRequestSpan(ctx, req)
+		res, err := client.Do(req.WithContext(spanCtx))
+		endRequestSpan(span, res, err)
+
+		if err != nil {
+			tflog.SubsystemError(ctx, logSubsystem, "Snyk API request failed", map[string]interface{}{"error": err.Error()})
+			return nil, err
+		}
+
+		logResponse(ctx, res, time.Since(start))
+
+		if so.RateLimiter != nil {
+			so.RateLimiter.observe(res)
+		}
+
+		if res.StatusCode < 300 {
+			return res, nil
+		}
+
+		retry := res.StatusCode == http.StatusTooManyRequests && so.RateLimiter != nil && attempt < rateLimitRetries
+
+		if res.StatusCode == http.StatusUnauthorized && so.Tokens != nil && !reauthenticated {
+			so.Tokens.invalidate(token)
+			reauthenticated = true
+			retry = true
+		}
+
+		if retry {
+			res.Body.Close()
+
+			if req.GetBody != nil {
+				req.Body, _ = req.GetBody()
+			}
+
+			continue
+		}
+
+		defer res.Body.Close()
+
+		return nil, errorFn(res)
 	}
+}
 
-	if res.StatusCode < 300 {
-		return res, nil
-	} else if res.StatusCode == 401 {
-		return nil, fmt.Errorf("%w", ErrInvalidAuthn)
+func statusError(res *http.Response) error {
+	if res.StatusCode == 401 {
+		return fmt.Errorf("%w", ErrInvalidAuthn)
 	} else if res.StatusCode == 403 {
-		return nil, fmt.Errorf("%w", ErrInvalidAuthz)
+		return fmt.Errorf("%w", ErrInvalidAuthz)
 	} else if res.StatusCode == 404 {
-		return nil, fmt.Errorf("%w", ErrNotFound)
+		return fmt.Errorf("%w", ErrNotFound)
 	} else {
-		return nil, fmt.Errorf("%w", ErrUnexpectedStatus)
+		return fmt.Errorf("%w", ErrUnexpectedStatus)
 	}
 }
 
@@ -50,7 +159,18 @@ func generateHeaders(so SnykOptions, req *http.Request) {
 	req.Header.Set("User-Agent", so.UserAgent)
 }
 
-func constructUrl(path string) string {
-	snykEndpoint := "https://snyk.io/api/v1%s"
-	return fmt.Sprintf(snykEndpoint, path)
+func (so SnykOptions) endpoint() string {
+	if so.Endpoint == "" {
+		return DefaultEndpoint
+	}
+
+	return strings.TrimSuffix(so.Endpoint, "/")
+}
+
+func constructUrl(so SnykOptions, path string) string {
+	return fmt.Sprintf("%s/v1%s", so.endpoint(), path)
+}
+
+func constructRestUrl(so SnykOptions, path string, version string) string {
+	return fmt.Sprintf("%s/rest%s", so.endpoint(), withQuery(path, fmt.Sprintf("version=%s", version)))
 }
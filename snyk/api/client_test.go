@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+)
+
+// Every call converts error statuses into the same errors, whichever API it uses.
+func TestErrorStatuses(t *testing.T) {
+	const orgId = "9e1f2a3b-4c5d-4e6f-8a7b-0c1d2e3f4a5b"
+	const id = "0c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+
+	var status int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/rest/") {
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"jsonapi": {"version": "1.0"}, "errors": [{"status": "%d", "detail": "failed"}]}`, status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"code": %d, "message": "failed", "error": "failed"}`, status)
+	}))
+	defer server.Close()
+
+	so := SnykOptions{GroupId: testGroupId, ApiKey: "test-api-key", Endpoint: server.URL}
+	ignore := Ignore{OrgId: orgId, ProjectId: id, IssueId: "SNYK-JS-A", Path: "*", ReasonType: "wont-fix"}
+	group := ServiceAccountScope{GroupId: testGroupId}
+
+	calls := map[string]func(context.Context) error{
+		"ListGroupTags":  func(ctx context.Context) error { return errorOf(ListGroupTags(ctx, so)) },
+		"DeleteGroupTag": func(ctx context.Context) error { return DeleteGroupTag(ctx, so, GroupTag{Key: "a", Value: "b"}, false) },
+		"CreateIgnore":   func(ctx context.Context) error { return CreateIgnore(ctx, so, ignore) },
+		"GetIgnore":      func(ctx context.Context) error { return errorOf(GetIgnore(ctx, so, orgId, id, "SNYK-JS-A", "*")) },
+		"UpdateIgnore":   func(ctx context.Context) error { return UpdateIgnore(ctx, so, ignore) },
+		"DeleteIgnore":   func(ctx context.Context) error { return DeleteIgnore(ctx, so, orgId, id, "SNYK-JS-A", "*") },
+		"ListIgnores":    func(ctx context.Context) error { return errorOf(ListIgnores(ctx, so, orgId, id)) },
+		"CreateIntegration": func(ctx context.Context) error {
+			return errorOf(CreateIntegration(ctx, so, orgId, "github", IntegrationCredentials{}))
+		},
+		"GetIntegration":    func(ctx context.Context) error { return errorOf(GetIntegration(ctx, so, orgId, "github")) },
+		"IntegrationExists": func(ctx context.Context) error { return errorOf(IntegrationExists(ctx, so, orgId, "github")) },
+		"ListIntegrations":  func(ctx context.Context) error { return errorOf(ListIntegrations(ctx, so, orgId)) },
+		"UpdateIntegration": func(ctx context.Context) error {
+			return errorOf(UpdateIntegration(ctx, so, orgId, "github", IntegrationCredentials{}))
+		},
+		"DeleteIntegration":       func(ctx context.Context) error { return DeleteIntegration(ctx, so, orgId, "github") },
+		"GetNotificationSettings": func(ctx context.Context) error { return errorOf(GetNotificationSettings(ctx, so, orgId)) },
+		"UpdateNotificationSettings": func(ctx context.Context) error {
+			return errorOf(UpdateNotificationSettings(ctx, so, orgId, DefaultNotificationSettings))
+		},
+		"GetOrganization":          func(ctx context.Context) error { return errorOf(GetOrganization(ctx, so, orgId)) },
+		"OrganizationExistsByName": func(ctx context.Context) error { return errorOf(OrganizationExistsByName(ctx, so, "org")) },
+		"ListOrganizations":        func(ctx context.Context) error { return errorOf(ListOrganizations(ctx, so)) },
+		"CreateOrganization":       func(ctx context.Context) error { return errorOf(CreateOrganization(ctx, so, "org")) },
+		"DeleteOrganization":       func(ctx context.Context) error { return DeleteOrganization(ctx, so, orgId) },
+		"GetOrganizationSettings":  func(ctx context.Context) error { return errorOf(GetOrganizationSettings(ctx, so, orgId)) },
+		"UpdateOrganizationSettings": func(ctx context.Context) error {
+			return errorOf(UpdateOrganizationSettings(ctx, so, orgId, OrganizationSettings{}))
+		},
+		"CreatePolicy": func(ctx context.Context) error { return errorOf(CreatePolicy(ctx, so, Policy{})) },
+		"GetPolicy":    func(ctx context.Context) error { return errorOf(GetPolicy(ctx, so, id)) },
+		"UpdatePolicy": func(ctx context.Context) error { return errorOf(UpdatePolicy(ctx, so, id, Policy{})) },
+		"DeletePolicy": func(ctx context.Context) error { return DeletePolicy(ctx, so, id) },
+		"ListProjects": func(ctx context.Context) error { return errorOf(ListProjects(ctx, so, orgId)) },
+		"CreateServiceAccount": func(ctx context.Context) error {
+			return errorOf(CreateServiceAccount(ctx, so, group, "sa", "role", "api_key"))
+		},
+		"GetServiceAccount":          func(ctx context.Context) error { return errorOf(GetServiceAccount(ctx, so, group, id)) },
+		"UpdateServiceAccountName":   func(ctx context.Context) error { return errorOf(UpdateServiceAccountName(ctx, so, group, id, "sa")) },
+		"RotateServiceAccountSecret": func(ctx context.Context) error { return errorOf(RotateServiceAccountSecret(ctx, so, group, id)) },
+		"DeleteServiceAccount":       func(ctx context.Context) error { return DeleteServiceAccount(ctx, so, group, id) },
+		"ImportTarget": func(ctx context.Context) error {
+			return errorOf(ImportTarget(ctx, so, orgId, id, TargetImport{Name: "repo"}))
+		},
+		"GetImportJob": func(ctx context.Context) error { return errorOf(GetImportJob(ctx, so, orgId, id, id)) },
+		"GetTarget":    func(ctx context.Context) error { return errorOf(GetTarget(ctx, so, orgId, id)) },
+		"ListTargets":  func(ctx context.Context) error { return errorOf(ListTargets(ctx, so, orgId, "")) },
+		"DeleteTarget": func(ctx context.Context) error { return DeleteTarget(ctx, so, orgId, id) },
+		"CreateWebhook": func(ctx context.Context) error {
+			return errorOf(CreateWebhook(ctx, so, orgId, "https://example.com", "secret"))
+		},
+		"ListWebhooks":  func(ctx context.Context) error { return errorOf(ListWebhooks(ctx, so, orgId)) },
+		"PingWebhook":   func(ctx context.Context) error { return PingWebhook(ctx, so, orgId, id) },
+		"DeleteWebhook": func(ctx context.Context) error { return DeleteWebhook(ctx, so, orgId, id) },
+	}
+
+	statuses := map[int]error{
+		http.StatusUnauthorized:        ErrInvalidAuthn,
+		http.StatusForbidden:           ErrInvalidAuthz,
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusInternalServerError: ErrUnexpectedStatus,
+		http.StatusServiceUnavailable:  ErrUnexpectedStatus,
+	}
+
+	// deleting an ignore that's already gone succeeds
+	exceptions := map[string]map[int]error{
+		"DeleteIgnore": {http.StatusNotFound: nil},
+	}
+
+	for name, call := range calls {
+		for code, expected := range statuses {
+			if exception, ok := exceptions[name][code]; ok {
+				expected = exception
+			}
+
+			status = code
+
+			if err := call(context.Background()); !errors.Is(err, expected) {
+				t.Errorf("%s with status %d: expected %v, got: %v", name, code, expected, err)
+			}
+		}
+	}
+}
+
+// errorOf drops a call's result, keeping its error.
+func errorOf[T any](_ T, err error) error {
+	return err
+}
+
+// fakeOrganization starts a fake Snyk API with an organization to work in.
+func fakeOrganization(t *testing.T) (*fakesnyk.Server, SnykOptions, string) {
+	server := fakesnyk.NewServer()
+	t.Cleanup(server.Close)
+
+	so := SnykOptions{GroupId: fakesnyk.GroupId, ApiKey: fakesnyk.ApiKey, Endpoint: server.URL}
+
+	org, err := CreateOrganization(context.Background(), so, "Test Org")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, so, org.Id
+}
+
+func TestConstructUrl(t *testing.T) {
+	if url := constructUrl(SnykOptions{}, "/org/abc"); url != "https://api.snyk.io/v1/org/abc" {
+		t.Errorf("bad v1 url: %s", url)
+	}
+
+	so := SnykOptions{Endpoint: "https://api.eu.snyk.io/"}
+
+	if url := constructRestUrl(so, "/orgs/abc/targets?limit=10", "2024-10-15"); url != "https://api.eu.snyk.io/rest/orgs/abc/targets?limit=10&version=2024-10-15" {
+		t.Errorf("bad REST url: %s", url)
+	}
+}
+
+func TestRequestDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	so := SnykOptions{Endpoint: server.URL}
+
+	if _, err := GetOrganization(ctx, so, "abc"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to stop at the deadline, got: %v", err)
+	}
+}
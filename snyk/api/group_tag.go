@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type GroupTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type groupTagDeleteRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Force bool   `json:"force"`
+}
+
+const groupTagsPerPage = 1000
+
+func ListGroupTags(ctx context.Context, so SnykOptions) ([]GroupTag, error) {
+	path := fmt.Sprintf("/group/%s/tags", so.GroupId)
+
+	var tags []GroupTag
+
+	it := newV1Iterator(ctx, so, path, "tags", groupTagsPerPage)
+	for it.Next() {
+		var tag GroupTag
+
+		if err := it.Decode(&tag); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// DeleteGroupTag removes a tag from the group. Unless force is set, Snyk refuses to delete tags that are
+// still applied to projects.
+func DeleteGroupTag(ctx context.Context, so SnykOptions, tag GroupTag, force bool) error {
+	path := fmt.Sprintf("/group/%s/tags/delete", so.GroupId)
+
+	body, _ := json.Marshal(groupTagDeleteRequest{
+		Key:   tag.Key,
+		Value: tag.Value,
+		Force: force,
+	})
+
+	res, err := clientDo(ctx, so, "POST", path, body)
+
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
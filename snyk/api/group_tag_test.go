@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+)
+
+func TestGroupTags(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	so := SnykOptions{GroupId: fakesnyk.GroupId, ApiKey: fakesnyk.ApiKey, Endpoint: server.URL}
+
+	// more tags than fit on a page, to follow the v1 pagination
+	for i := 0; i < groupTagsPerPage+5; i++ {
+		server.AddGroupTag(fakesnyk.GroupId, "team", fmt.Sprintf("team-%d", i), false)
+	}
+	server.AddGroupTag(fakesnyk.GroupId, "env", "prod", true)
+
+	tags, err := ListGroupTags(ctx, so)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tags) != groupTagsPerPage+6 || tags[len(tags)-1] != (GroupTag{Key: "env", Value: "prod"}) {
+		t.Errorf("expected every tag to be listed, got %d", len(tags))
+	}
+
+	inUse := GroupTag{Key: "env", Value: "prod"}
+
+	if err := DeleteGroupTag(ctx, so, inUse, false); !errors.Is(err, ErrUnexpectedStatus) {
+		t.Errorf("expected a tag in use not to be deleted without force, got: %v", err)
+	}
+
+	if err := DeleteGroupTag(ctx, so, inUse, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if remaining := server.GroupTags(fakesnyk.GroupId); len(remaining) != groupTagsPerPage+5 {
+		t.Errorf("expected only the tag in use to be deleted, got %d left", len(remaining))
+	}
+
+	if tags, err := ListGroupTags(ctx, so.InGroup(fakesnyk.OtherGroupId)); err != nil || len(tags) != 0 {
+		t.Errorf("expected no tags in the other group, got: %#v, %v", tags, err)
+	}
+}
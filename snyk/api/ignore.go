@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type Ignore struct {
+	OrgId      string
+	ProjectId  string
+	IssueId    string
+	Path       string
+	Reason     string
+	ReasonType string
+	IgnoredBy  string
+	Created    time.Time
+	Expires    *time.Time
+}
+
+type ignoreRequest struct {
+	IgnorePath string     `json:"ignorePath"`
+	Reason     string     `json:"reason,omitempty"`
+	ReasonType string     `json:"reasonType"`
+	Expires    *time.Time `json:"expires,omitempty"`
+}
+
+type ignoreDetail struct {
+	Reason     string     `json:"reason"`
+	ReasonType string     `json:"reasonType"`
+	Created    time.Time  `json:"created"`
+	Expires    *time.Time `json:"expires,omitempty"`
+	IgnoredBy  struct {
+		Email string `json:"email"`
+	} `json:"ignoredBy"`
+}
+
+// ignoreRules is how Snyk returns the ignores for a single issue, one map per rule keyed by the ignored path.
+type ignoreRules []map[string]ignoreDetail
+
+// Expired reports whether the ignore had an expiry set which has since passed.
+func (i Ignore) Expired(now time.Time) bool {
+	return i.Expires != nil && i.Expires.Before(now)
+}
+
+func CreateIgnore(ctx context.Context, so SnykOptions, ignore Ignore) error {
+	path := fmt.Sprintf("/org/%s/project/%s/ignore/%s", ignore.OrgId, ignore.ProjectId, ignore.IssueId)
+
+	body, _ := json.Marshal(newIgnoreRequest(ignore))
+
+	return ignoreRequestDo(ctx, so, "POST", path, body)
+}
+
+func GetIgnore(ctx context.Context, so SnykOptions, orgId string, projectId string, issueId string, ignorePath string) (*Ignore, error) {
+	ignores, err := getIssueIgnores(ctx, so, orgId, projectId, issueId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ignore := range ignores {
+		if ignore.Path == ignorePath {
+			return &ignore, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// UpdateIgnore replaces the issue's rule for the ignore's path. Snyk only replaces an issue's rules as a
+// whole, so the issue's other rules are sent back unchanged.
+func UpdateIgnore(ctx context.Context, so SnykOptions, ignore Ignore) error {
+	ignores, err := getIssueIgnores(ctx, so, ignore.OrgId, ignore.ProjectId, ignore.IssueId)
+
+	if err != nil {
+		return err
+	}
+
+	rules := []ignoreRequest{newIgnoreRequest(ignore)}
+	for _, other := range ignores {
+		if other.Path != ignore.Path {
+			rules = append(rules, newIgnoreRequest(other))
+		}
+	}
+
+	return replaceIgnores(ctx, so, ignore.OrgId, ignore.ProjectId, ignore.IssueId, rules)
+}
+
+// DeleteIgnore removes the issue's rule for a path, keeping the issue's other rules.
+func DeleteIgnore(ctx context.Context, so SnykOptions, orgId string, projectId string, issueId string, ignorePath string) error {
+	ignores, err := getIssueIgnores(ctx, so, orgId, projectId, issueId)
+
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var rules []ignoreRequest
+	for _, other := range ignores {
+		if other.Path != ignorePath {
+			rules = append(rules, newIgnoreRequest(other))
+		}
+	}
+
+	if len(rules) == len(ignores) {
+		return nil
+	}
+
+	// an empty list of rules isn't accepted, but deleting the issue's ignores removes them all
+	if len(rules) == 0 {
+		path := fmt.Sprintf("/org/%s/project/%s/ignore/%s", orgId, projectId, issueId)
+
+		_, err := clientDo(ctx, so, "DELETE", path, nil)
+
+		return err
+	}
+
+	return replaceIgnores(ctx, so, orgId, projectId, issueId, rules)
+}
+
+func ListIgnores(ctx context.Context, so SnykOptions, orgId string, projectId string) ([]Ignore, error) {
+	path := fmt.Sprintf("/org/%s/project/%s/ignores", orgId, projectId)
+
+	res, err := clientDo(ctx, so, "GET", path, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var listing map[string]ignoreRules
+	err = json.NewDecoder(res.Body).Decode(&listing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	issueIds := make([]string, 0, len(listing))
+	for issueId := range listing {
+		issueIds = append(issueIds, issueId)
+	}
+	sort.Strings(issueIds)
+
+	var ignores []Ignore
+	for _, issueId := range issueIds {
+		ignores = append(ignores, listing[issueId].toIgnores(orgId, projectId, issueId)...)
+	}
+
+	return ignores, nil
+}
+
+func getIssueIgnores(ctx context.Context, so SnykOptions, orgId string, projectId string, issueId string) ([]Ignore, error) {
+	path := fmt.Sprintf("/org/%s/project/%s/ignore/%s", orgId, projectId, issueId)
+
+	res, err := clientDo(ctx, so, "GET", path, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var rules ignoreRules
+	err = json.NewDecoder(res.Body).Decode(&rules)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rules.toIgnores(orgId, projectId, issueId), nil
+}
+
+func replaceIgnores(ctx context.Context, so SnykOptions, orgId string, projectId string, issueId string, rules []ignoreRequest) error {
+	path := fmt.Sprintf("/org/%s/project/%s/ignore/%s", orgId, projectId, issueId)
+
+	body, _ := json.Marshal(rules)
+
+	return ignoreRequestDo(ctx, so, "PUT", path, body)
+}
+
+func newIgnoreRequest(ignore Ignore) ignoreRequest {
+	return ignoreRequest{
+		IgnorePath: ignore.Path,
+		Reason:     ignore.Reason,
+		ReasonType: ignore.ReasonType,
+		Expires:    ignore.Expires,
+	}
+}
+
+func ignoreRequestDo(ctx context.Context, so SnykOptions, method string, path string, body []byte) error {
+	res, err := clientDo(ctx, so, method, path, body)
+
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
+
+func (r ignoreRules) toIgnores(orgId string, projectId string, issueId string) []Ignore {
+	var ignores []Ignore
+
+	for _, rule := range r {
+		for ignorePath, detail := range rule {
+			ignores = append(ignores, Ignore{
+				OrgId:      orgId,
+				ProjectId:  projectId,
+				IssueId:    issueId,
+				Path:       ignorePath,
+				Reason:     detail.Reason,
+				ReasonType: detail.ReasonType,
+				IgnoredBy:  detail.IgnoredBy.Email,
+				Created:    detail.Created,
+				Expires:    detail.Expires,
+			})
+		}
+	}
+
+	return ignores
+}
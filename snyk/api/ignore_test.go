@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+)
+
+// Snyk only replaces or deletes an issue's ignores as a whole, so changing one path's rule mustn't disturb the
+// others.
+func TestIgnoreRulesArePerPath(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	so := SnykOptions{GroupId: fakesnyk.GroupId, ApiKey: fakesnyk.ApiKey, Endpoint: server.URL}
+
+	org, err := CreateOrganization(ctx, so, "Test Org")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ignore := func(path string, reason string) Ignore {
+		return Ignore{OrgId: org.Id, ProjectId: "project", IssueId: "issue", Path: path, Reason: reason, ReasonType: "wont-fix"}
+	}
+
+	for _, path := range []string{"a", "b"} {
+		if err := CreateIgnore(ctx, so, ignore(path, "created")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := UpdateIgnore(ctx, so, ignore("a", "updated")); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, reason := range map[string]string{"a": "updated", "b": "created"} {
+		found, err := GetIgnore(ctx, so, org.Id, "project", "issue", path)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if found.Reason != reason {
+			t.Errorf("expected the rule for %s to have reason %q, got %q", path, reason, found.Reason)
+		}
+	}
+
+	if err := DeleteIgnore(ctx, so, org.Id, "project", "issue", "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetIgnore(ctx, so, org.Id, "project", "issue", "a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the rule for a to be deleted, got: %v", err)
+	}
+
+	if _, err := GetIgnore(ctx, so, org.Id, "project", "issue", "b"); err != nil {
+		t.Errorf("expected the rule for b to be kept, got: %v", err)
+	}
+
+	if err := DeleteIgnore(ctx, so, org.Id, "project", "issue", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if rules := server.Ignores(org.Id, "project", "issue"); len(rules) != 0 {
+		t.Errorf("expected no rules left, got: %#v", rules)
+	}
+}
+
+func TestListIgnores(t *testing.T) {
+	_, so, orgId := fakeOrganization(t)
+	ctx := context.Background()
+
+	for _, ignore := range []Ignore{
+		{OrgId: orgId, ProjectId: "project", IssueId: "SNYK-JS-B", Path: "*", ReasonType: "wont-fix"},
+		{OrgId: orgId, ProjectId: "project", IssueId: "SNYK-JS-A", Path: "a > b", Reason: "not reachable", ReasonType: "not-vulnerable"},
+		{OrgId: orgId, ProjectId: "project", IssueId: "SNYK-JS-A", Path: "c > b", ReasonType: "temporary-ignore"},
+		{OrgId: orgId, ProjectId: "other", IssueId: "SNYK-JS-C", Path: "*", ReasonType: "wont-fix"},
+	} {
+		if err := CreateIgnore(ctx, so, ignore); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ignores, err := ListIgnores(ctx, so, orgId, "project")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sorted by issue
+	if len(ignores) != 3 || ignores[0].IssueId != "SNYK-JS-A" || ignores[2].IssueId != "SNYK-JS-B" {
+		t.Fatalf("unexpected ignores: %#v", ignores)
+	}
+
+	if ignores[0].Reason != "not reachable" || ignores[0].ProjectId != "project" || ignores[0].IgnoredBy != "fake@example.com" {
+		t.Errorf("unexpected ignore: %#v", ignores[0])
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -22,7 +23,7 @@ type IntegrationCredentials struct {
 	RoleArn      string `json:"roleArn,omitempty"`
 }
 
-func CreateIntegration(so SnykOptions, orgId string, intType string, creds IntegrationCredentials) (*Integration, error) {
+func CreateIntegration(ctx context.Context, so SnykOptions, orgId string, intType string, creds IntegrationCredentials) (*Integration, error) {
 	path := fmt.Sprintf("/org/%s/integrations", orgId)
 
 	i := Integration{
@@ -32,7 +33,7 @@ func CreateIntegration(so SnykOptions, orgId string, intType string, creds Integ
 
 	body, _ := json.Marshal(i)
 
-	res, err := clientDo(so, "POST", path, body)
+	res, err := clientDo(ctx, so, "POST", path, body)
 
 	if err != nil {
 		return nil, err
@@ -57,8 +58,8 @@ func CreateIntegration(so SnykOptions, orgId string, intType string, creds Integ
 	return returnData, nil
 }
 
-func GetIntegration(so SnykOptions, orgId string, intType string) (*Integration, error) {
-	id, err := getIntegrationIdByType(so, orgId, intType)
+func GetIntegration(ctx context.Context, so SnykOptions, orgId string, intType string) (*Integration, error) {
+	id, err := getIntegrationIdByType(ctx, so, orgId, intType)
 
 	if err != nil {
 		return nil, err
@@ -71,10 +72,10 @@ func GetIntegration(so SnykOptions, orgId string, intType string) (*Integration,
 	}, nil
 }
 
-func getIntegrationIdByType(so SnykOptions, orgId string, intType string) (string, error) {
+func getIntegrationIdByType(ctx context.Context, so SnykOptions, orgId string, intType string) (string, error) {
 	path := fmt.Sprintf("/org/%s/integrations/%s", orgId, intType)
 
-	res, err := clientDo(so, "GET", path, nil)
+	res, err := clientDo(ctx, so, "GET", path, nil)
 
 	if err != nil {
 		return "", err
@@ -92,13 +93,26 @@ func getIntegrationIdByType(so SnykOptions, orgId string, intType string) (strin
 	return data["id"], nil
 }
 
-func IntegrationExists(so SnykOptions, org string, intType string) (bool, error) {
+func IntegrationExists(ctx context.Context, so SnykOptions, org string, intType string) (bool, error) {
+	listing, err := ListIntegrations(ctx, so, org)
+
+	if err != nil {
+		return false, err
+	}
+
+	exists := listing[intType] != ""
+
+	return exists, nil
+}
+
+// ListIntegrations returns the IDs of an organization's integrations, by integration type.
+func ListIntegrations(ctx context.Context, so SnykOptions, org string) (map[string]string, error) {
 	path := fmt.Sprintf("/org/%s/integrations", org)
 
-	res, err := clientDo(so, "GET", path, nil)
+	res, err := clientDo(ctx, so, "GET", path, nil)
 
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	defer res.Body.Close()
@@ -107,17 +121,15 @@ func IntegrationExists(so SnykOptions, org string, intType string) (bool, error)
 	err = json.NewDecoder(res.Body).Decode(&listing)
 
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	exists := listing[intType] != ""
-
-	return exists, nil
+	return listing, nil
 }
 
-func UpdateIntegration(so SnykOptions, orgId string, intType string, creds IntegrationCredentials) (*Integration, error) {
+func UpdateIntegration(ctx context.Context, so SnykOptions, orgId string, intType string, creds IntegrationCredentials) (*Integration, error) {
 
-	id, err := getIntegrationIdByType(so, orgId, intType)
+	id, err := getIntegrationIdByType(ctx, so, orgId, intType)
 
 	if err != nil {
 		return nil, err
@@ -132,7 +144,7 @@ func UpdateIntegration(so SnykOptions, orgId string, intType string, creds Integ
 
 	body, _ := json.Marshal(patchData)
 
-	_, err = clientDo(so, "PUT", path, body)
+	_, err = clientDo(ctx, so, "PUT", path, body)
 
 	if err != nil {
 		return nil, err
@@ -148,9 +160,9 @@ func UpdateIntegration(so SnykOptions, orgId string, intType string, creds Integ
 	return returnData, nil
 }
 
-func DeleteIntegration(so SnykOptions, orgId string, intType string) error {
+func DeleteIntegration(ctx context.Context, so SnykOptions, orgId string, intType string) error {
 
-	id, err := getIntegrationIdByType(so, orgId, intType)
+	id, err := getIntegrationIdByType(ctx, so, orgId, intType)
 
 	if err != nil {
 		return err
@@ -158,7 +170,7 @@ func DeleteIntegration(so SnykOptions, orgId string, intType string) error {
 
 	path := fmt.Sprintf("/org/%s/integrations/%s/authentication", orgId, id)
 
-	_, err = clientDo(so, "DELETE", path, nil)
+	_, err = clientDo(ctx, so, "DELETE", path, nil)
 
 	return err
 }
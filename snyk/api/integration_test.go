@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIntegrationCassette(t *testing.T) {
+	ctx := context.Background()
+	so := cassetteOptions(t, "integration_lifecycle")
+
+	org, err := CreateOrganization(ctx, so, "tf-acc-test-cassette-integration")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exists, err := IntegrationExists(ctx, so, org.Id, "bitbucket-cloud"); err != nil || exists {
+		t.Fatalf("expected no integration, got: %v, %v", exists, err)
+	}
+
+	creds := IntegrationCredentials{Username: "test_user", Password: "test_pass"}
+	created, err := CreateIntegration(ctx, so, org.Id, "bitbucket-cloud", creds)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exists, err := IntegrationExists(ctx, so, org.Id, "bitbucket-cloud"); err != nil || !exists {
+		t.Errorf("expected the integration to exist, got: %v, %v", exists, err)
+	}
+
+	if listing, err := ListIntegrations(ctx, so, org.Id); err != nil || listing["bitbucket-cloud"] != created.Id {
+		t.Errorf("expected the integration to be listed, got: %v, %v", listing, err)
+	}
+
+	found, err := GetIntegration(ctx, so, org.Id, "bitbucket-cloud")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Id != created.Id || found.Type != "bitbucket-cloud" {
+		t.Errorf("unexpected integration: %#v", found)
+	}
+
+	creds.Password = "rotated_pass"
+	updated, err := UpdateIntegration(ctx, so, org.Id, "bitbucket-cloud", creds)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.Id != created.Id {
+		t.Errorf("expected the same integration to be updated, got: %#v", updated)
+	}
+
+	if err := DeleteIntegration(ctx, so, org.Id, "bitbucket-cloud"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DeleteOrganization(ctx, so, org.Id); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// jsonApiDocument is the JSON:API top level document used by the REST API, for both requests and responses.
+type jsonApiDocument struct {
+	Data   json.RawMessage      `json:"data,omitempty"`
+	Links  *jsonApiLinks        `json:"links,omitempty"`
+	Errors []JsonApiErrorObject `json:"errors,omitempty"`
+}
+
+type jsonApiResource struct {
+	Id            string          `json:"id,omitempty"`
+	Type          string          `json:"type"`
+	Attributes    json.RawMessage `json:"attributes,omitempty"`
+	Relationships json.RawMessage `json:"relationships,omitempty"`
+}
+
+type jsonApiLinks struct {
+	Next string `json:"next,omitempty"`
+}
+
+type JsonApiErrorObject struct {
+	Id     string `json:"id,omitempty"`
+	Status string `json:"status"`
+	Code   string `json:"code,omitempty"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+// RestError is returned when the REST API responds with an error document. It wraps the same errors as the
+// v1 API (ErrNotFound etc.) so callers can check for them with errors.Is, whichever API was used.
+type RestError struct {
+	StatusCode int
+	Errors     []JsonApiErrorObject
+	err        error
+}
+
+func (e *RestError) Error() string {
+	details := make([]string, 0, len(e.Errors))
+	for _, obj := range e.Errors {
+		details = append(details, fmt.Sprintf("%s: %s", obj.Title, obj.Detail))
+	}
+
+	if len(details) == 0 {
+		return e.err.Error()
+	}
+
+	return fmt.Sprintf("%s (%s)", e.err, strings.Join(details, "; "))
+}
+
+func (e *RestError) Unwrap() error {
+	return e.err
+}
+
+func jsonApiError(res *http.Response) error {
+	var doc jsonApiDocument
+
+	// error bodies are informational, so a malformed one still leaves the status error
+	json.NewDecoder(res.Body).Decode(&doc)
+
+	return &RestError{
+		StatusCode: res.StatusCode,
+		Errors:     doc.Errors,
+		err:        statusError(res),
+	}
+}
+
+func (r jsonApiResource) decodeAttributes(v interface{}) error {
+	return json.Unmarshal(r.Attributes, v)
+}
+
+func (r jsonApiResource) decodeRelationships(v interface{}) error {
+	if len(r.Relationships) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(r.Relationships, v)
+}
+
+func newJsonApiResource(resourceType string, id string, attributes interface{}) jsonApiResource {
+	encoded, _ := json.Marshal(attributes)
+
+	return jsonApiResource{
+		Id:         id,
+		Type:       resourceType,
+		Attributes: encoded,
+	}
+}
+
+func restGet(ctx context.Context, so SnykOptions, path string, version string) (*jsonApiResource, error) {
+	return restSend(ctx, so, "GET", path, version, nil)
+}
+
+// restSend wraps the resource (if any) in a document, and returns the resource from the response.
+func restSend(ctx context.Context, so SnykOptions, method string, path string, version string, resource *jsonApiResource) (*jsonApiResource, error) {
+	var body []byte
+	if resource != nil {
+		data, _ := json.Marshal(resource)
+		body, _ = json.Marshal(jsonApiDocument{Data: data})
+	}
+
+	res, err := restClientDo(ctx, so, method, path, version, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var doc jsonApiDocument
+	err = json.NewDecoder(res.Body).Decode(&doc)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result = new(jsonApiResource)
+	err = json.Unmarshal(doc.Data, result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// restNextPath turns a links.next value into a path for restClientDo. Links may be absolute or relative, with
+// or without the /rest prefix, and carry the version they were requested with which is pinned again per call.
+func restNextPath(next string) (string, error) {
+	u, err := url.Parse(next)
+
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Del("version")
+
+	path := strings.TrimPrefix(u.Path, "/rest")
+	if len(query) > 0 {
+		path = fmt.Sprintf("%s?%s", path, query.Encode())
+	}
+
+	return path, nil
+}
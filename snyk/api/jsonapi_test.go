@@ -0,0 +1,47 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRestNextPath(t *testing.T) {
+	cases := map[string]string{
+		"/orgs/abc/targets?limit=10&starting_after=xyz&version=2024-10-15":                         "/orgs/abc/targets?limit=10&starting_after=xyz",
+		"/rest/orgs/abc/targets?starting_after=xyz&version=2024-10-15":                             "/orgs/abc/targets?starting_after=xyz",
+		"https://api.snyk.io/rest/groups/abc/orgs?limit=100&starting_after=xyz&version=2024-10-15": "/groups/abc/orgs?limit=100&starting_after=xyz",
+	}
+
+	for next, expected := range cases {
+		path, err := restNextPath(next)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if path != expected {
+			t.Errorf("bad path for %s, expected %q, got: %q", next, expected, path)
+		}
+	}
+}
+
+func TestJsonApiError(t *testing.T) {
+	res := &http.Response{
+		StatusCode: 404,
+		Body:       io.NopCloser(strings.NewReader(`{"jsonapi":{"version":"1.0"},"errors":[{"status":"404","title":"Not Found","detail":"org abc not found"}]}`)),
+	}
+
+	err := jsonApiError(res)
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected error to wrap ErrNotFound, got: %s", err)
+	}
+
+	var restErr *RestError
+	if !errors.As(err, &restErr) || len(restErr.Errors) != 1 || restErr.Errors[0].Detail != "org abc not found" {
+		t.Errorf("expected error objects to be parsed, got: %#v", err)
+	}
+}
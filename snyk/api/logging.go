@@ -0,0 +1,146 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const logSubsystem = "snyk_api"
+
+const redacted = "***"
+
+// credentialFields are JSON body fields that hold secrets, such as those of IntegrationCredentials and
+// service accounts. Their values are replaced before bodies are logged.
+var credentialFields = map[string]bool{
+	"access_token":  true,
+	"api_key":       true,
+	"client_secret": true,
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+}
+
+// requestLogger returns a context that logs to the API subsystem with the request's fields set, and the
+// API key masked wherever it would appear.
+func requestLogger(so SnykOptions, req *http.Request) context.Context {
+	ctx := tflog.NewSubsystem(req.Context(), logSubsystem)
+
+	if so.ApiKey != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, so.ApiKey)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, logSubsystem, so.ApiKey)
+	}
+
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, "http_method", req.Method)
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, "http_path", req.URL.RequestURI())
+
+	return ctx
+}
+
+func logRequest(ctx context.Context, req *http.Request) {
+	tflog.SubsystemDebug(ctx, logSubsystem, "sending Snyk API request")
+
+	var body []byte
+	if req.GetBody != nil {
+		if r, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(r)
+		}
+	}
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "Snyk API request detail", map[string]interface{}{
+		"http_headers": redactHeaders(req.Header),
+		"http_body":    redactBody(body),
+	})
+}
+
+// logResponse logs the response, buffering its body so that it can still be read by the caller.
+func logResponse(ctx context.Context, res *http.Response, duration time.Duration) {
+	fields := map[string]interface{}{
+		"http_status":   res.StatusCode,
+		"duration_ms":   duration.Milliseconds(),
+		"request_id":    requestId(res),
+		"http_response": res.Status,
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "received Snyk API response", fields)
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		tflog.SubsystemWarn(ctx, logSubsystem, "failed to read Snyk API response body", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "Snyk API response detail", map[string]interface{}{
+		"http_headers": redactHeaders(res.Header),
+		"http_body":    redactBody(body),
+	})
+}
+
+func requestId(res *http.Response) string {
+	for _, header := range []string{"Snyk-Request-Id", "X-Request-Id"} {
+		if id := res.Header.Get(header); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+func redactHeaders(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+
+	for key, values := range headers {
+		if strings.EqualFold(key, "Authorization") {
+			result[key] = redacted
+		} else {
+			result[key] = strings.Join(values, ", ")
+		}
+	}
+
+	return result
+}
+
+// redactBody replaces credential fields anywhere in a JSON body. Bodies that aren't JSON are left out
+// entirely, as there's no telling what they contain.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "(non-JSON body omitted)"
+	}
+
+	encoded, _ := json.Marshal(redactValue(v))
+
+	return string(encoded)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if credentialFields[strings.ToLower(key)] {
+				value[key] = redacted
+			} else {
+				value[key] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRequestLoggingRedactsCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Snyk-Request-Id", "req-123")
+		w.Write([]byte(`{"id":"abc","credentials":{"token":"response-token"}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	so := SnykOptions{ApiKey: "secret-api-key"}
+	body := []byte(`{"type":"github","credentials":{"username":"bot","password":"hunter2","token":"request-token"}}`)
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", server.URL+"/org/abc/integrations", bytes.NewReader(body))
+	generateHeaders(so, req)
+
+	res, err := doRequest(so, req, statusError)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var response bytes.Buffer
+	response.ReadFrom(res.Body)
+	res.Body.Close()
+
+	if !strings.Contains(response.String(), "response-token") {
+		t.Errorf("expected the response body to still be readable, got: %s", response.String())
+	}
+
+	logs := output.String()
+
+	for _, secret := range []string{"secret-api-key", "hunter2", "request-token", "response-token"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("expected %q to be redacted from logs:\n%s", secret, logs)
+		}
+	}
+
+	for _, expected := range []string{`"http_method":"POST"`, `"http_path":"/org/abc/integrations"`, `"http_status":200`, `"request_id":"req-123"`, `bot`} {
+		if !strings.Contains(logs, expected) {
+			t.Errorf("expected logs to contain %s:\n%s", expected, logs)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type NotificationSettings struct {
+	NewIssues       NewIssuesNotification `json:"new-issues-remediations"`
+	ProjectImported NotificationSetting   `json:"project-imported"`
+	TestLimit       NotificationSetting   `json:"test-limit"`
+	WeeklyReport    NotificationSetting   `json:"weekly-report"`
+}
+
+type NotificationSetting struct {
+	Enabled   bool `json:"enabled"`
+	Inherited bool `json:"inherited,omitempty"`
+}
+
+type NewIssuesNotification struct {
+	Enabled       bool   `json:"enabled"`
+	IssueSeverity string `json:"issueSeverity"`
+	IssueType     string `json:"issueType"`
+	Inherited     bool   `json:"inherited,omitempty"`
+}
+
+// DefaultNotificationSettings are the settings a newly created organization starts with.
+var DefaultNotificationSettings = NotificationSettings{
+	NewIssues: NewIssuesNotification{
+		Enabled:       true,
+		IssueSeverity: "all",
+		IssueType:     "all",
+	},
+	ProjectImported: NotificationSetting{Enabled: true},
+	TestLimit:       NotificationSetting{Enabled: true},
+	WeeklyReport:    NotificationSetting{Enabled: true},
+}
+
+func GetNotificationSettings(ctx context.Context, so SnykOptions, orgId string) (*NotificationSettings, error) {
+	path := fmt.Sprintf("/org/%s/notification-settings", orgId)
+
+	res, err := clientDo(ctx, so, "GET", path, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var settings = new(NotificationSettings)
+	err = json.NewDecoder(res.Body).Decode(settings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func UpdateNotificationSettings(ctx context.Context, so SnykOptions, orgId string, settings NotificationSettings) (*NotificationSettings, error) {
+	path := fmt.Sprintf("/org/%s/notification-settings", orgId)
+
+	// inherited is reported by Snyk but can't be set
+	settings.NewIssues.Inherited = false
+	settings.ProjectImported.Inherited = false
+	settings.TestLimit.Inherited = false
+	settings.WeeklyReport.Inherited = false
+
+	body, _ := json.Marshal(settings)
+
+	res, err := clientDo(ctx, so, "PUT", path, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var updated = new(NotificationSettings)
+	err = json.NewDecoder(res.Body).Decode(updated)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
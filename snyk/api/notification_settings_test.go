@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNotificationSettings(t *testing.T) {
+	_, so, orgId := fakeOrganization(t)
+	ctx := context.Background()
+
+	settings := DefaultNotificationSettings
+	settings.NewIssues.IssueSeverity = "high"
+	settings.WeeklyReport = NotificationSetting{Enabled: false, Inherited: true}
+
+	updated, err := UpdateNotificationSettings(ctx, so, orgId, settings)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// inherited can't be set, so it's never sent
+	if updated.WeeklyReport != (NotificationSetting{}) {
+		t.Errorf("expected the weekly report to be disabled and not inherited, got: %#v", updated.WeeklyReport)
+	}
+
+	found, err := GetNotificationSettings(ctx, so, orgId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.NewIssues.IssueSeverity != "high" || !found.ProjectImported.Enabled || found.WeeklyReport.Enabled {
+		t.Errorf("unexpected settings: %#v", found)
+	}
+}
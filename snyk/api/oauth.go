@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AccessToken is a short-lived OAuth access token, such as a Snyk service account gets for its client
+// credentials.
+type AccessToken struct {
+	AccessToken string
+	TokenType   string
+	Scope       string
+	Expiry      time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// ExchangeClientCredentials exchanges a client ID and secret for an access token, through the OAuth client
+// credentials grant. The token endpoint lives alongside the v1 and REST APIs, and takes no other credentials.
+func ExchangeClientCredentials(ctx context.Context, so SnykOptions, clientId string, clientSecret string) (*AccessToken, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", so.endpoint()+"/oauth2/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", so.UserAgent)
+
+	res, err := doRequest(so, req, oauthError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var data tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	return &AccessToken{
+		AccessToken: data.AccessToken,
+		TokenType:   data.TokenType,
+		Scope:       data.Scope,
+		Expiry:      time.Now().Add(time.Duration(data.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// oauthError converts an error response from the token endpoint, in the form RFC 6749 gives it, into the
+// errors used for the rest of the API.
+func oauthError(res *http.Response) error {
+	var data struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+
+	json.NewDecoder(res.Body).Decode(&data)
+
+	err := statusError(res)
+	if data.Error == "invalid_client" {
+		err = ErrInvalidAuthn
+	}
+
+	if data.Error == "" {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s: %s", err, data.Error, data.Description)
+}
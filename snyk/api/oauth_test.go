@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+)
+
+func TestExchangeClientCredentials(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	so := SnykOptions{GroupId: fakesnyk.GroupId, Endpoint: server.URL}
+
+	token, err := ExchangeClientCredentials(ctx, so, fakesnyk.ClientId, fakesnyk.ClientSecret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.AccessToken == "" || token.TokenType != "bearer" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	if until := time.Until(token.Expiry); until < 59*time.Minute || until > time.Hour {
+		t.Errorf("expected the token to expire in an hour, got: %s", until)
+	}
+
+	_, err = ExchangeClientCredentials(ctx, so, fakesnyk.ClientId, "wrong")
+
+	if !errors.Is(err, ErrInvalidAuthn) {
+		t.Errorf("expected ErrInvalidAuthn for a wrong secret, got: %v", err)
+	}
+}
+
+func TestTokenSource(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	so := SnykOptions{GroupId: fakesnyk.GroupId, Endpoint: server.URL}
+	so.Tokens = NewTokenSource(so, fakesnyk.ClientId, fakesnyk.ClientSecret)
+
+	if _, err := ListOrganizations(ctx, so); err != nil {
+		t.Fatalf("expected an access token to authenticate requests, got: %s", err)
+	}
+
+	first, _ := so.Tokens.Token(ctx)
+
+	if second, _ := so.Tokens.Token(ctx); second != first {
+		t.Error("expected the token to be reused until it's due to be refreshed")
+	}
+
+	so.Tokens.refreshAt = time.Now()
+
+	if refreshed, _ := so.Tokens.Token(ctx); refreshed == first {
+		t.Error("expected a new token once the old one is due to be refreshed")
+	}
+
+	// tokens the API rejects are replaced, and the request retried
+	server.RevokeTokens()
+
+	if _, err := ListOrganizations(ctx, so); err != nil {
+		t.Errorf("expected a revoked token to be replaced, got: %s", err)
+	}
+}
+
+func TestTokenSourceRefreshMargin(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	server.TokenLifetime = 6 * time.Minute
+
+	ts := NewTokenSource(SnykOptions{Endpoint: server.URL}, fakesnyk.ClientId, fakesnyk.ClientSecret)
+
+	if _, err := ts.Token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// short-lived tokens are refreshed halfway through their lifetime
+	if margin := ts.token.Expiry.Sub(ts.refreshAt); margin > 3*time.Minute || margin < 3*time.Minute-time.Second {
+		t.Errorf("expected a 3m refresh margin, got: %s", margin)
+	}
+}
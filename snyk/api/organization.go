@@ -1,11 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 )
 
+const organizationApiVersion = "2024-10-15"
+
 type Organization struct {
 	Id      string    `json:"id,omitempty"`
 	Name    string    `json:"name"`
@@ -14,33 +17,27 @@ type Organization struct {
 	Created time.Time `json:"created,omitempty"`
 }
 
+// organizationAttributes are the REST API representation of an organization. The REST API has no URL
+// for an organization, so it's built from the slug in the same form the v1 API returned.
+type organizationAttributes struct {
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	GroupId   string    `json:"group_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type organizationCreateRequest struct {
 	Name    string `json:"name"`
 	GroupId string `json:"groupId"`
 }
 
-func GetOrganization(so SnykOptions, id string) (*Organization, error) {
-	path := fmt.Sprintf("/group/%s/orgs", so.GroupId)
-
-	res, err := clientDo(so, "GET", path, nil)
+func GetOrganization(ctx context.Context, so SnykOptions, id string) (*Organization, error) {
+	orgs, err := listGroupOrganizations(ctx, so)
 
 	if err != nil {
 		return nil, err
 	}
 
-	defer res.Body.Close()
-
-	group := map[string]json.RawMessage{}
-
-	err = json.NewDecoder(res.Body).Decode(&group)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var orgs []Organization
-	json.Unmarshal(group["orgs"], &orgs)
-
 	for _, element := range orgs {
 		if element.Id == id {
 			return &element, nil
@@ -50,38 +47,82 @@ func GetOrganization(so SnykOptions, id string) (*Organization, error) {
 	return nil, ErrNotFound
 }
 
-func OrganizationExistsByName(so SnykOptions, name string) (bool, error) {
-	path := fmt.Sprintf("/group/%s/orgs", so.GroupId)
-
-	res, err := clientDo(so, "GET", path, nil)
+func OrganizationExistsByName(ctx context.Context, so SnykOptions, name string) (bool, error) {
+	orgs, err := listGroupOrganizations(ctx, so)
 
 	if err != nil {
 		return false, err
 	}
 
-	defer res.Body.Close()
+	for _, element := range orgs {
+		if element.Name == name {
+			return true, nil
+		}
+	}
 
-	group := map[string]json.RawMessage{}
+	return false, nil
+}
 
-	err = json.NewDecoder(res.Body).Decode(&group)
+// InGroup returns a copy of the options for another group, or the same group when groupId is empty. The
+// copy shares the original's organization cache, rate limiter and credentials.
+func (so SnykOptions) InGroup(groupId string) SnykOptions {
+	if groupId != "" {
+		so.GroupId = groupId
+	}
 
-	if err != nil {
-		return false, err
+	return so
+}
+
+// ListOrganizations returns every organization in the group.
+func ListOrganizations(ctx context.Context, so SnykOptions) ([]Organization, error) {
+	return listGroupOrganizations(ctx, so)
+}
+
+func listGroupOrganizations(ctx context.Context, so SnykOptions) ([]Organization, error) {
+	if so.Organizations == nil {
+		return fetchGroupOrganizations(ctx, so)
 	}
 
+	return so.Organizations.get(so.GroupId, func() ([]Organization, error) {
+		return fetchGroupOrganizations(ctx, so)
+	})
+}
+
+func fetchGroupOrganizations(ctx context.Context, so SnykOptions) ([]Organization, error) {
+	path := fmt.Sprintf("/groups/%s/orgs?limit=%d", so.GroupId, defaultPageSize)
+
 	var orgs []Organization
-	json.Unmarshal(group["orgs"], &orgs)
 
-	for _, element := range orgs {
-		if element.Name == name {
-			return true, nil
+	it := newRestIterator(ctx, so, path, organizationApiVersion)
+	for it.Next() {
+		var resource jsonApiResource
+		var attributes organizationAttributes
+
+		if err := it.Decode(&resource); err != nil {
+			return nil, err
 		}
+
+		if err := resource.decodeAttributes(&attributes); err != nil {
+			return nil, err
+		}
+
+		orgs = append(orgs, Organization{
+			Id:      resource.Id,
+			Name:    attributes.Name,
+			Slug:    attributes.Slug,
+			Url:     fmt.Sprintf("https://app.snyk.io/org/%s", attributes.Slug),
+			Created: attributes.CreatedAt,
+		})
 	}
 
-	return false, nil
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
 }
 
-func CreateOrganization(so SnykOptions, name string) (*Organization, error) {
+func CreateOrganization(ctx context.Context, so SnykOptions, name string) (*Organization, error) {
 	path := "/org"
 
 	newOrg := organizationCreateRequest{
@@ -91,7 +132,7 @@ func CreateOrganization(so SnykOptions, name string) (*Organization, error) {
 
 	body, _ := json.Marshal(newOrg)
 
-	res, err := clientDo(so, "POST", path, body)
+	res, err := clientDo(ctx, so, "POST", path, body)
 
 	if err != nil {
 		return nil, err
@@ -99,6 +140,8 @@ func CreateOrganization(so SnykOptions, name string) (*Organization, error) {
 
 	defer res.Body.Close()
 
+	so.invalidateOrganizations()
+
 	var org = new(Organization)
 	err = json.NewDecoder(res.Body).Decode(org)
 
@@ -109,10 +152,16 @@ func CreateOrganization(so SnykOptions, name string) (*Organization, error) {
 	return org, nil
 }
 
-func DeleteOrganization(so SnykOptions, id string) error {
+func DeleteOrganization(ctx context.Context, so SnykOptions, id string) error {
 	path := fmt.Sprintf("/org/%s", id)
 
-	_, err := clientDo(so, "DELETE", path, nil)
+	_, err := clientDo(ctx, so, "DELETE", path, nil)
+
+	if err != nil {
+		return err
+	}
+
+	so.invalidateOrganizations()
 
-	return err
+	return nil
 }
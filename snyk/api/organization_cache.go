@@ -0,0 +1,98 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// OrganizationCache holds each group's organization listing for a short time, so that reading many
+// organizations in one run doesn't download the whole listing for each of them. Concurrent lookups
+// while a group's listing is missing share a single request.
+type OrganizationCache struct {
+	ttl    time.Duration
+	flight singleflight.Group
+
+	mu     sync.Mutex
+	groups map[string]*groupOrganizations
+}
+
+type groupOrganizations struct {
+	orgs       []Organization
+	expires    time.Time
+	generation int
+}
+
+func NewOrganizationCache(ttl time.Duration) *OrganizationCache {
+	return &OrganizationCache{ttl: ttl, groups: map[string]*groupOrganizations{}}
+}
+
+func (c *OrganizationCache) get(groupId string, fetch func() ([]Organization, error)) ([]Organization, error) {
+	c.mu.Lock()
+	group := c.group(groupId)
+	if group.orgs != nil && time.Now().Before(group.expires) {
+		orgs := group.orgs
+		c.mu.Unlock()
+		return orgs, nil
+	}
+	generation := group.generation
+	c.mu.Unlock()
+
+	// requests started before an invalidation don't share with (or populate the cache for) those after it
+	v, err, _ := c.flight.Do(fmt.Sprintf("%s/%d", groupId, generation), func() (interface{}, error) {
+		orgs, err := fetch()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if orgs == nil {
+			orgs = []Organization{}
+		}
+
+		c.mu.Lock()
+		if group := c.group(groupId); group.generation == generation {
+			group.orgs = orgs
+			group.expires = time.Now().Add(c.ttl)
+		}
+		c.mu.Unlock()
+
+		return orgs, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]Organization), nil
+}
+
+// invalidate discards a group's cached listing, after an organization has been created or deleted in it.
+func (c *OrganizationCache) invalidate(groupId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	group := c.group(groupId)
+	group.orgs = nil
+	group.generation++
+}
+
+// group returns the cache entry for a group, which c.mu must be held for.
+func (c *OrganizationCache) group(groupId string) *groupOrganizations {
+	group, ok := c.groups[groupId]
+
+	if !ok {
+		group = &groupOrganizations{}
+		c.groups[groupId] = group
+	}
+
+	return group
+}
+
+func (so SnykOptions) invalidateOrganizations() {
+	if so.Organizations != nil {
+		so.Organizations.invalidate(so.GroupId)
+	}
+}
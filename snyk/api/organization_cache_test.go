@@ -0,0 +1,80 @@
+package api
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOrganizationCacheSharesFetches(t *testing.T) {
+	cache := NewOrganizationCache(time.Minute)
+
+	var calls int32
+	release := make(chan struct{})
+	fetch := func() ([]Organization, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []Organization{{Id: "abc"}}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if orgs, err := cache.get("group", fetch); err != nil || len(orgs) != 1 {
+				t.Errorf("unexpected result: %v, %v", orgs, err)
+			}
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	cache.get("group", fetch)
+
+	if calls != 1 {
+		t.Errorf("expected a single fetch, got %d", calls)
+	}
+}
+
+func TestOrganizationCacheExpiry(t *testing.T) {
+	calls := 0
+	fetch := func() ([]Organization, error) {
+		calls++
+		return nil, nil
+	}
+
+	cache := NewOrganizationCache(time.Minute)
+	cache.get("group", fetch)
+	cache.get("group", fetch)
+
+	if calls != 1 {
+		t.Errorf("expected an empty listing to be cached, got %d fetches", calls)
+	}
+
+	cache.invalidate("group")
+	cache.get("group", fetch)
+
+	if calls != 2 {
+		t.Errorf("expected invalidate to force a fetch, got %d fetches", calls)
+	}
+
+	cache.get("other", fetch)
+	cache.invalidate("other")
+	cache.get("group", fetch)
+
+	if calls != 3 {
+		t.Errorf("expected each group to be cached separately, got %d fetches", calls)
+	}
+
+	expired := NewOrganizationCache(0)
+	expired.get("group", fetch)
+	expired.get("group", fetch)
+
+	if calls != 5 {
+		t.Errorf("expected an expired listing to be fetched again, got %d fetches", calls)
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// OrganizationSettings mirrors the v1 organization settings API, which only documents the request access
+// section. Sections left nil are omitted from updates, so Snyk keeps whatever value is currently configured.
+type OrganizationSettings struct {
+	RequestAccess *RequestAccessSettings `json:"requestAccess,omitempty"`
+}
+
+type RequestAccessSettings struct {
+	Enabled bool `json:"enabled"`
+}
+
+func GetOrganizationSettings(ctx context.Context, so SnykOptions, orgId string) (*OrganizationSettings, error) {
+	path := fmt.Sprintf("/org/%s/settings", orgId)
+
+	res, err := clientDo(ctx, so, "GET", path, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var settings = new(OrganizationSettings)
+	err = json.NewDecoder(res.Body).Decode(settings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func UpdateOrganizationSettings(ctx context.Context, so SnykOptions, orgId string, settings OrganizationSettings) (*OrganizationSettings, error) {
+	path := fmt.Sprintf("/org/%s/settings", orgId)
+
+	body, _ := json.Marshal(settings)
+
+	res, err := clientDo(ctx, so, "PUT", path, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var updated = new(OrganizationSettings)
+	err = json.NewDecoder(res.Body).Decode(updated)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
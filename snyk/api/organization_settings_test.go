@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOrganizationSettings(t *testing.T) {
+	_, so, orgId := fakeOrganization(t)
+	ctx := context.Background()
+
+	settings, err := GetOrganizationSettings(ctx, so, orgId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Snyk leaves out sections it has no value for
+	if settings.RequestAccess != nil {
+		t.Errorf("expected no request access settings, got: %#v", settings.RequestAccess)
+	}
+
+	updated, err := UpdateOrganizationSettings(ctx, so, orgId, OrganizationSettings{RequestAccess: &RequestAccessSettings{Enabled: true}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.RequestAccess == nil || !updated.RequestAccess.Enabled {
+		t.Errorf("expected request access to be enabled, got: %#v", updated.RequestAccess)
+	}
+
+	// unset sections are left out of updates, keeping their current values
+	if _, err := UpdateOrganizationSettings(ctx, so, orgId, OrganizationSettings{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if settings, err := GetOrganizationSettings(ctx, so, orgId); err != nil || settings.RequestAccess == nil || !settings.RequestAccess.Enabled {
+		t.Errorf("expected request access to stay enabled, got: %#v, %v", settings, err)
+	}
+}
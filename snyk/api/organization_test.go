@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+)
+
+func TestOrganizationLifecycle(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	so := SnykOptions{
+		GroupId:       fakesnyk.GroupId,
+		ApiKey:        fakesnyk.ApiKey,
+		Endpoint:      server.URL,
+		Organizations: NewOrganizationCache(time.Minute),
+	}
+
+	org, err := CreateOrganization(ctx, so, "Test Org")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// more projects than fit on a page, to follow the REST pagination links
+	for i := 0; i < defaultPageSize+5; i++ {
+		server.AddProject(org.Id, "owner/repo", "npm", "github")
+	}
+
+	found, err := GetOrganization(ctx, so, org.Id)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Name != "Test Org" || found.Url != "https://app.snyk.io/org/test-org" {
+		t.Errorf("unexpected organization: %#v", found)
+	}
+
+	projects, err := ListProjects(ctx, so, org.Id)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(projects) != defaultPageSize+5 || projects[0].Origin != "github" {
+		t.Errorf("expected every project to be listed, got %d", len(projects))
+	}
+
+	if err := DeleteOrganization(ctx, so, org.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if exists, _ := OrganizationExistsByName(ctx, so, "Test Org"); exists {
+		t.Error("expected the organization to be gone after deleting it")
+	}
+
+	if _, err := GetOrganization(ctx, so, org.Id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
+
+func TestInvalidCredentials(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	so := SnykOptions{GroupId: fakesnyk.GroupId, ApiKey: "wrong", Endpoint: server.URL}
+
+	if _, err := GetOrganization(context.Background(), so, "abc"); !errors.Is(err, ErrInvalidAuthn) {
+		t.Errorf("expected ErrInvalidAuthn, got: %v", err)
+	}
+}
+
+func TestOrganizationCassette(t *testing.T) {
+	ctx := context.Background()
+	so := cassetteOptions(t, "organization_lifecycle")
+
+	org, err := CreateOrganization(ctx, so, "tf-acc-test-cassette")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orgs, err := ListOrganizations(ctx, so)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.ContainsFunc(orgs, func(o Organization) bool { return o.Id == org.Id && o.Name == org.Name }) {
+		t.Errorf("expected the organization to be listed, got: %#v", orgs)
+	}
+
+	found, err := GetOrganization(ctx, so, org.Id)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Name != "tf-acc-test-cassette" || found.Url != "https://app.snyk.io/org/tf-acc-test-cassette" {
+		t.Errorf("unexpected organization: %#v", found)
+	}
+
+	if exists, err := OrganizationExistsByName(ctx, so, org.Name); err != nil || !exists {
+		t.Errorf("expected organization to exist, got: %v, %v", exists, err)
+	}
+
+	if err := DeleteOrganization(ctx, so, org.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if exists, err := OrganizationExistsByName(ctx, so, org.Name); err != nil || exists {
+		t.Errorf("expected organization not to exist, got: %v, %v", exists, err)
+	}
+
+	if _, err := GetOrganization(ctx, so, org.Id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultPageSize = 100
+
+// pageIterator walks every item of a paginated listing, fetching further pages as they are needed.
+//
+//	it := newRestIterator(ctx, so, path, version)
+//	for it.Next() {
+//		var resource jsonApiResource
+//		if err := it.Decode(&resource); err != nil { ... }
+//	}
+//	if err := it.Err(); err != nil { ... }
+type pageIterator struct {
+	fetch   pageFetcher
+	cursor  string
+	items   []json.RawMessage
+	current json.RawMessage
+	done    bool
+	err     error
+}
+
+// pageFetcher returns the items of the page at cursor, and the cursor of the following page ("" once exhausted).
+type pageFetcher func(cursor string) ([]json.RawMessage, string, error)
+
+func newPageIterator(first string, fetch pageFetcher) *pageIterator {
+	return &pageIterator{
+		fetch:  fetch,
+		cursor: first,
+	}
+}
+
+// newV1Iterator pages through a v1 listing using the perPage/page parameters. The v1 API doesn't say whether
+// there are more pages, so a page with fewer than perPage items is taken to be the last one.
+func newV1Iterator(ctx context.Context, so SnykOptions, path string, itemsKey string, perPage int) *pageIterator {
+	return newPageIterator("1", func(cursor string) ([]json.RawMessage, string, error) {
+		page, _ := strconv.Atoi(cursor)
+
+		res, err := clientDo(ctx, so, "GET", withQuery(path, fmt.Sprintf("perPage=%d&page=%d", perPage, page)), nil)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		defer res.Body.Close()
+
+		listing := map[string]json.RawMessage{}
+		err = json.NewDecoder(res.Body).Decode(&listing)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		var items []json.RawMessage
+		if raw, ok := listing[itemsKey]; ok {
+			err = json.Unmarshal(raw, &items)
+
+			if err != nil {
+				return nil, "", err
+			}
+		}
+
+		if len(items) < perPage {
+			return items, "", nil
+		}
+
+		return items, strconv.Itoa(page + 1), nil
+	})
+}
+
+// newRestIterator pages through a REST collection by following the links.next cursor. Items decode
+// into a jsonApiResource.
+func newRestIterator(ctx context.Context, so SnykOptions, path string, version string) *pageIterator {
+	return newPageIterator(path, func(cursor string) ([]json.RawMessage, string, error) {
+		res, err := restClientDo(ctx, so, "GET", cursor, version, nil)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		defer res.Body.Close()
+
+		var doc jsonApiDocument
+		err = json.NewDecoder(res.Body).Decode(&doc)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		var items []json.RawMessage
+		err = json.Unmarshal(doc.Data, &items)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		if doc.Links == nil || doc.Links.Next == "" {
+			return items, "", nil
+		}
+
+		next, err := restNextPath(doc.Links.Next)
+
+		return items, next, err
+	})
+}
+
+// Next advances to the following item, fetching the next page if required. It returns false once every
+// item has been visited or an error occurred.
+func (it *pageIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		items, next, err := it.fetch(it.cursor)
+
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items = items
+		it.cursor = next
+		it.done = next == ""
+	}
+
+	it.current = it.items[0]
+	it.items = it.items[1:]
+
+	return true
+}
+
+// Decode unmarshals the current item into v.
+func (it *pageIterator) Decode(v interface{}) error {
+	return json.Unmarshal(it.current, v)
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *pageIterator) Err() error {
+	return it.err
+}
+
+func withQuery(path string, query string) string {
+	if strings.Contains(path, "?") {
+		return fmt.Sprintf("%s&%s", path, query)
+	}
+
+	return fmt.Sprintf("%s?%s", path, query)
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPageIterator(t *testing.T) {
+	pages := map[string][]json.RawMessage{
+		"1": {json.RawMessage(`1`), json.RawMessage(`2`)},
+		"2": {},
+		"3": {json.RawMessage(`3`)},
+	}
+	next := map[string]string{"1": "2", "2": "3", "3": ""}
+
+	it := newPageIterator("1", func(cursor string) ([]json.RawMessage, string, error) {
+		return pages[cursor], next[cursor], nil
+	})
+
+	var got []int
+	for it.Next() {
+		var n int
+		if err := it.Decode(&n); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, n)
+	}
+
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("expected items from every page, got: %v", got)
+	}
+}
+
+func TestPageIteratorError(t *testing.T) {
+	calls := 0
+	it := newPageIterator("1", func(cursor string) ([]json.RawMessage, string, error) {
+		calls++
+		if cursor == "2" {
+			return nil, "", ErrUnexpectedStatus
+		}
+		return []json.RawMessage{json.RawMessage(`1`)}, "2", nil
+	})
+
+	for it.Next() {
+	}
+
+	if !errors.Is(it.Err(), ErrUnexpectedStatus) {
+		t.Errorf("expected iteration to stop with the fetch error, got: %v", it.Err())
+	}
+
+	if it.Next() || calls != 2 {
+		t.Errorf("expected no further fetches after an error, got %d calls", calls)
+	}
+}
+
+func TestWithQuery(t *testing.T) {
+	if q := withQuery("/orgs/abc/targets", "version=1"); q != "/orgs/abc/targets?version=1" {
+		t.Errorf("bad query: %s", q)
+	}
+
+	if q := withQuery("/orgs/abc/targets?limit=10", "version=1"); q != "/orgs/abc/targets?limit=10&version=1" {
+		t.Errorf("bad query: %s", q)
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type Policy struct {
+	Id            string               `json:"id,omitempty"`
+	Name          string               `json:"name"`
+	Description   string               `json:"description,omitempty"`
+	Type          string               `json:"type"`
+	LicenseRules  []LicensePolicyRule  `json:"licenseRules,omitempty"`
+	SecurityRules []SecurityPolicyRule `json:"securityRules,omitempty"`
+	Attachments   PolicyAttachments    `json:"attachments"`
+}
+
+type LicensePolicyRule struct {
+	License      string `json:"license"`
+	Severity     string `json:"severity"`
+	Instructions string `json:"instructions,omitempty"`
+}
+
+type SecurityPolicyRule struct {
+	Name       string                    `json:"name"`
+	Conditions []SecurityPolicyCondition `json:"conditions"`
+	Action     SecurityPolicyAction      `json:"action"`
+}
+
+type SecurityPolicyCondition struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+type SecurityPolicyAction struct {
+	Type       string `json:"type"`
+	Severity   string `json:"severity,omitempty"`
+	IgnoreType string `json:"ignoreType,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// PolicyAttachments controls what a group policy applies to - either a set of organizations, or every project
+// matching the given attributes.
+type PolicyAttachments struct {
+	Organizations     []string           `json:"organizations,omitempty"`
+	ProjectAttributes *ProjectAttributes `json:"projectAttributes,omitempty"`
+}
+
+type ProjectAttributes struct {
+	Criticality []string `json:"criticality,omitempty"`
+	Environment []string `json:"environment,omitempty"`
+	Lifecycle   []string `json:"lifecycle,omitempty"`
+}
+
+const (
+	PolicyTypeLicense  = "license"
+	PolicyTypeSecurity = "security"
+)
+
+func CreatePolicy(ctx context.Context, so SnykOptions, policy Policy) (*Policy, error) {
+	path := fmt.Sprintf("/group/%s/policies", so.GroupId)
+
+	body, _ := json.Marshal(policy)
+
+	return policyRequest(ctx, so, "POST", path, body)
+}
+
+func GetPolicy(ctx context.Context, so SnykOptions, id string) (*Policy, error) {
+	path := fmt.Sprintf("/group/%s/policies/%s", so.GroupId, id)
+
+	return policyRequest(ctx, so, "GET", path, nil)
+}
+
+func UpdatePolicy(ctx context.Context, so SnykOptions, id string, policy Policy) (*Policy, error) {
+	path := fmt.Sprintf("/group/%s/policies/%s", so.GroupId, id)
+
+	body, _ := json.Marshal(policy)
+
+	return policyRequest(ctx, so, "PUT", path, body)
+}
+
+func DeletePolicy(ctx context.Context, so SnykOptions, id string) error {
+	path := fmt.Sprintf("/group/%s/policies/%s", so.GroupId, id)
+
+	_, err := clientDo(ctx, so, "DELETE", path, nil)
+
+	return err
+}
+
+func policyRequest(ctx context.Context, so SnykOptions, method string, path string, body []byte) (*Policy, error) {
+	res, err := clientDo(ctx, so, method, path, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var policy = new(Policy)
+	err = json.NewDecoder(res.Body).Decode(policy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
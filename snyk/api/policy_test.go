@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+)
+
+func TestPolicyLifecycle(t *testing.T) {
+	_, so, orgId := fakeOrganization(t)
+	ctx := context.Background()
+
+	// policies can be in a group other than the provider's
+	so = so.InGroup(fakesnyk.OtherGroupId)
+
+	created, err := CreatePolicy(ctx, so, Policy{
+		Name: "Licenses",
+		Type: PolicyTypeLicense,
+		LicenseRules: []LicensePolicyRule{
+			{License: "AGPL-3.0-only", Severity: "high"},
+		},
+		Attachments: PolicyAttachments{Organizations: []string{orgId}},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.Id == "" || len(created.LicenseRules) != 1 || created.Attachments.Organizations[0] != orgId {
+		t.Errorf("unexpected policy: %#v", created)
+	}
+
+	updated, err := UpdatePolicy(ctx, so, created.Id, Policy{
+		Name: "Licenses",
+		Type: PolicyTypeLicense,
+		LicenseRules: []LicensePolicyRule{
+			{License: "AGPL-3.0-only", Severity: "medium", Instructions: "Ask legal"},
+		},
+		Attachments: PolicyAttachments{ProjectAttributes: &ProjectAttributes{Environment: []string{"external"}}},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.Id != created.Id {
+		t.Errorf("expected the same policy to be updated, got: %#v", updated)
+	}
+
+	found, err := GetPolicy(ctx, so, created.Id)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.LicenseRules[0].Instructions != "Ask legal" || found.Attachments.ProjectAttributes == nil || len(found.Attachments.Organizations) != 0 {
+		t.Errorf("unexpected policy: %#v", found)
+	}
+
+	if _, err := GetPolicy(ctx, so.InGroup(fakesnyk.GroupId), created.Id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the policy not to be in the provider's group, got: %v", err)
+	}
+
+	if err := DeletePolicy(ctx, so, created.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetPolicy(ctx, so, created.Id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+const projectApiVersion = "2024-10-15"
+
+type Project struct {
+	Id     string `json:"-"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Origin string `json:"origin"`
+}
+
+func ListProjects(ctx context.Context, so SnykOptions, orgId string) ([]Project, error) {
+	path := fmt.Sprintf("/orgs/%s/projects?limit=%d", orgId, defaultPageSize)
+
+	var projects []Project
+
+	it := newRestIterator(ctx, so, path, projectApiVersion)
+	for it.Next() {
+		var resource jsonApiResource
+		var project Project
+
+		if err := it.Decode(&resource); err != nil {
+			return nil, err
+		}
+
+		if err := resource.decodeAttributes(&project); err != nil {
+			return nil, err
+		}
+
+		project.Id = resource.Id
+		projects = append(projects, project)
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitRetries is how many times a throttled (429) request is retried, after waiting as long as the
+// server asked.
+const rateLimitRetries = 3
+
+// RateLimiter spaces out requests to the Snyk API, shared by every resource operation Terraform runs in
+// parallel. Besides the configured token bucket, it pauses all requests when the server reports that the
+// limit has been reached.
+type RateLimiter struct {
+	limiter *rate.Limiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+func NewRateLimiter(requestsPerMinute int, burst int) *RateLimiter {
+	return &RateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(float64(requestsPerMinute)/60), burst),
+	}
+}
+
+// wait blocks until a request may be sent, or the context is cancelled.
+func (r *RateLimiter) wait(ctx context.Context) error {
+	r.mu.Lock()
+	paused := time.Until(r.pausedUntil)
+	r.mu.Unlock()
+
+	if paused > 0 {
+		tflog.SubsystemInfo(ctx, logSubsystem, "Snyk API rate limit reached, waiting", map[string]interface{}{
+			"wait": paused.Round(time.Millisecond).String(),
+		})
+
+		if err := sleep(ctx, paused); err != nil {
+			return err
+		}
+	}
+
+	reservation := r.limiter.Reserve()
+	delay := reservation.Delay()
+
+	if delay == 0 {
+		return nil
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "waiting for the client rate limit", map[string]interface{}{
+		"wait": delay.Round(time.Millisecond).String(),
+	})
+
+	if err := sleep(ctx, delay); err != nil {
+		reservation.Cancel()
+		return err
+	}
+
+	return nil
+}
+
+// observe pauses further requests if the response says the server's limit has been reached, either with
+// Retry-After on a 429 or with X-RateLimit-Remaining running out before X-RateLimit-Reset.
+func (r *RateLimiter) observe(res *http.Response) {
+	var until time.Time
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		until = time.Now().Add(retryAfter(res))
+	} else if res.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, ok := parseReset(res.Header.Get("X-RateLimit-Reset")); ok {
+			until = reset
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if until.After(r.pausedUntil) {
+		r.pausedUntil = until
+	}
+}
+
+// retryAfter reads the Retry-After header, either in seconds or as an HTTP date, defaulting to a second.
+func retryAfter(res *http.Response) time.Duration {
+	value := res.Header.Get("Retry-After")
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+
+	return time.Second
+}
+
+// parseReset reads X-RateLimit-Reset, which is either the number of seconds until the window resets or,
+// for large values, the Unix time at which it does.
+func parseReset(value string) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+
+	if seconds > 1000000000 {
+		return time.Unix(seconds, 0), true
+	}
+
+	return time.Now().Add(time.Duration(seconds) * time.Second), true
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterRetriesThrottledRequests(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	so := SnykOptions{RateLimiter: NewRateLimiter(6000, 10)}
+	req, _ := http.NewRequestWithContext(context.Background(), "POST", server.URL, bytes.NewReader([]byte("{}")))
+
+	res, err := doRequest(so, req, statusError)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if len(bodies) != 2 || bodies[1] != "{}" {
+		t.Errorf("expected the request to be retried with its body, got: %q", bodies)
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	r := NewRateLimiter(6000, 10)
+
+	r.observe(&http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Ratelimit-Remaining": {"0"},
+			"X-Ratelimit-Reset":     {"30"},
+		},
+	})
+
+	if paused := time.Until(r.pausedUntil); paused < 29*time.Second || paused > 30*time.Second {
+		t.Errorf("expected requests to be paused until the reset, got: %s", paused)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := r.wait(ctx); err != context.Canceled {
+		t.Errorf("expected waiting to stop with the context, got: %v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	res := &http.Response{Header: http.Header{"Retry-After": {"5"}}}
+
+	if d := retryAfter(res); d != 5*time.Second {
+		t.Errorf("expected 5s, got: %s", d)
+	}
+
+	res.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+
+	if d := retryAfter(res); d < 58*time.Second || d > time.Minute {
+		t.Errorf("expected about a minute, got: %s", d)
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+)
+
+func TestServiceAccountLifecycle(t *testing.T) {
+	_, so, orgId := fakeOrganization(t)
+	ctx := context.Background()
+
+	scopes := map[string]ServiceAccountScope{
+		"group":        {GroupId: fakesnyk.OtherGroupId},
+		"organization": {OrgId: orgId},
+	}
+
+	for name, scope := range scopes {
+		t.Run(name, func(t *testing.T) {
+			created, err := CreateServiceAccount(ctx, so, scope, "ci", "role", "oauth_client_secret")
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if created.Id == "" || created.ClientId == "" || created.ClientSecret == "" {
+				t.Errorf("expected the new account's credentials, got: %#v", created)
+			}
+
+			renamed, err := UpdateServiceAccountName(ctx, so, scope, created.Id, "ci-renamed")
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if renamed.Name != "ci-renamed" || renamed.ClientSecret != "" {
+				t.Errorf("unexpected service account: %#v", renamed)
+			}
+
+			rotated, err := RotateServiceAccountSecret(ctx, so, scope, created.Id)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rotated.ClientSecret == "" || rotated.ClientSecret == created.ClientSecret {
+				t.Errorf("expected a new client secret, got: %#v", rotated)
+			}
+
+			found, err := GetServiceAccount(ctx, so, scope, created.Id)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if found.Name != "ci-renamed" || found.AuthType != "oauth_client_secret" || found.ClientId != created.ClientId {
+				t.Errorf("unexpected service account: %#v", found)
+			}
+
+			if err := DeleteServiceAccount(ctx, so, scope, created.Id); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := GetServiceAccount(ctx, so, scope, created.Id); !errors.Is(err, ErrNotFound) {
+				t.Errorf("expected ErrNotFound, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestServiceAccountApiKey(t *testing.T) {
+	server, so, _ := fakeOrganization(t)
+	ctx := context.Background()
+
+	scope := ServiceAccountScope{GroupId: so.GroupId}
+
+	created, err := CreateServiceAccount(ctx, so, scope, "ci", "role", "api_key")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.ApiKey == "" || created.ClientId != "" || server.ServiceAccount(fakesnyk.GroupId, created.Id) == nil {
+		t.Errorf("expected an API key for an account in the group, got: %#v", created)
+	}
+
+	// API keys can't be rotated, the account has to be replaced
+	if _, err := RotateServiceAccountSecret(ctx, so, scope, created.Id); !errors.Is(err, ErrUnexpectedStatus) {
+		t.Errorf("expected ErrUnexpectedStatus, got: %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const targetApiVersion = "2024-10-15"
+
+type Target struct {
+	Id              string
+	OrgId           string
+	DisplayName     string
+	Url             string
+	IsPrivate       bool
+	Created         time.Time
+	IntegrationId   string
+	IntegrationType string
+}
+
+// TargetImport describes the repository (or image) to import through an integration. Which fields are
+// required depends on the integration type.
+type TargetImport struct {
+	Owner  string `json:"owner,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Branch string `json:"branch,omitempty"`
+}
+
+type ImportJob struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type targetAttributes struct {
+	DisplayName string    `json:"display_name"`
+	Url         string    `json:"url"`
+	IsPrivate   bool      `json:"is_private"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type targetRelationships struct {
+	Integration struct {
+		Data struct {
+			Id         string `json:"id"`
+			Attributes struct {
+				IntegrationType string `json:"integration_type"`
+			} `json:"attributes"`
+		} `json:"data"`
+	} `json:"integration"`
+}
+
+type targetImportRequest struct {
+	Target TargetImport `json:"target"`
+}
+
+var ErrImportFailed = errors.New("import job failed")
+
+// ImportTarget starts importing a target through an integration, returning the ID of the import job.
+func ImportTarget(ctx context.Context, so SnykOptions, orgId string, integrationId string, target TargetImport) (string, error) {
+	path := fmt.Sprintf("/org/%s/integrations/%s/import", orgId, integrationId)
+
+	body, _ := json.Marshal(targetImportRequest{Target: target})
+
+	res, err := clientDo(ctx, so, "POST", path, body)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	// the job ID is only returned as the last segment of the job's URL
+	location, err := url.Parse(res.Header.Get("Location"))
+
+	if err != nil || location.Path == "" {
+		return "", fmt.Errorf("import job location missing from response: %w", ErrUnexpectedStatus)
+	}
+
+	segments := strings.Split(strings.TrimSuffix(location.Path, "/"), "/")
+
+	return segments[len(segments)-1], nil
+}
+
+func GetImportJob(ctx context.Context, so SnykOptions, orgId string, integrationId string, jobId string) (*ImportJob, error) {
+	path := fmt.Sprintf("/org/%s/integrations/%s/import/%s", orgId, integrationId, jobId)
+
+	res, err := clientDo(ctx, so, "GET", path, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var job = new(ImportJob)
+	err = json.NewDecoder(res.Body).Decode(job)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func GetTarget(ctx context.Context, so SnykOptions, orgId string, id string) (*Target, error) {
+	path := fmt.Sprintf("/orgs/%s/targets/%s", orgId, id)
+
+	resource, err := restGet(ctx, so, path, targetApiVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newTarget(orgId, *resource)
+}
+
+// ListTargets lists the targets in an organization, optionally filtered to an exact display name.
+func ListTargets(ctx context.Context, so SnykOptions, orgId string, displayName string) ([]Target, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(defaultPageSize))
+	if displayName != "" {
+		query.Set("display_name", displayName)
+	}
+
+	path := fmt.Sprintf("/orgs/%s/targets?%s", orgId, query.Encode())
+
+	targets := make([]Target, 0)
+
+	it := newRestIterator(ctx, so, path, targetApiVersion)
+	for it.Next() {
+		var resource jsonApiResource
+
+		if err := it.Decode(&resource); err != nil {
+			return nil, err
+		}
+
+		target, err := newTarget(orgId, resource)
+
+		if err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, *target)
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// DeleteTarget deletes the target along with every project imported from it.
+func DeleteTarget(ctx context.Context, so SnykOptions, orgId string, id string) error {
+	path := fmt.Sprintf("/orgs/%s/targets/%s", orgId, id)
+
+	_, err := restClientDo(ctx, so, "DELETE", path, targetApiVersion, nil)
+
+	return err
+}
+
+func newTarget(orgId string, resource jsonApiResource) (*Target, error) {
+	var attributes targetAttributes
+	var relationships targetRelationships
+
+	if err := resource.decodeAttributes(&attributes); err != nil {
+		return nil, err
+	}
+
+	if err := resource.decodeRelationships(&relationships); err != nil {
+		return nil, err
+	}
+
+	return &Target{
+		Id:              resource.Id,
+		OrgId:           orgId,
+		DisplayName:     attributes.DisplayName,
+		Url:             attributes.Url,
+		IsPrivate:       attributes.IsPrivate,
+		Created:         attributes.CreatedAt,
+		IntegrationId:   relationships.Integration.Data.Id,
+		IntegrationType: relationships.Integration.Data.Attributes.IntegrationType,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTargetLifecycle(t *testing.T) {
+	_, so, orgId := fakeOrganization(t)
+	ctx := context.Background()
+
+	integration, err := CreateIntegration(ctx, so, orgId, "github", IntegrationCredentials{Token: "token"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"app", "lib"} {
+		jobId, err := ImportTarget(ctx, so, orgId, integration.Id, TargetImport{Owner: "owner", Name: name, Branch: "main"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		job, err := GetImportJob(ctx, so, orgId, integration.Id, jobId)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if job.Id != jobId || job.Status != "complete" {
+			t.Errorf("unexpected import job: %#v", job)
+		}
+	}
+
+	targets, err := ListTargets(ctx, so, orgId, "owner/lib")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(targets) != 1 || targets[0].DisplayName != "owner/lib" || targets[0].IntegrationId != integration.Id || targets[0].IntegrationType != "github" {
+		t.Fatalf("expected only the lib target, got: %#v", targets)
+	}
+
+	target, err := GetTarget(ctx, so, orgId, targets[0].Id)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *target != targets[0] {
+		t.Errorf("expected %#v, got: %#v", targets[0], target)
+	}
+
+	if err := DeleteTarget(ctx, so, orgId, target.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetTarget(ctx, so, orgId, target.Id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	if targets, err := ListTargets(ctx, so, orgId, ""); err != nil || len(targets) != 1 {
+		t.Errorf("expected the app target to be left, got: %#v, %v", targets, err)
+	}
+}
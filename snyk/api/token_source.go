@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenRefreshMargin is how long before expiry an access token is replaced, so that it doesn't expire in
+// flight. Tokens that live less than twice as long are replaced halfway through their lifetime instead.
+const tokenRefreshMargin = 5 * time.Minute
+
+// TokenSource holds the access token for a set of OAuth client credentials, exchanging them again for a new
+// token before the current one expires. It's shared by every copy of the options it's set on.
+type TokenSource struct {
+	mu           sync.Mutex
+	so           SnykOptions
+	clientId     string
+	clientSecret string
+	token        *AccessToken
+	refreshAt    time.Time
+}
+
+// NewTokenSource returns a TokenSource for the client credentials, exchanging them through the API the
+// options point at.
+func NewTokenSource(so SnykOptions, clientId string, clientSecret string) *TokenSource {
+	so.Tokens = nil
+
+	return &TokenSource{so: so, clientId: clientId, clientSecret: clientSecret}
+}
+
+// Token returns the current access token, exchanging the client credentials for a new one when there's no
+// token or it's due to be refreshed.
+func (ts *TokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != nil && time.Now().Before(ts.refreshAt) {
+		return ts.token.AccessToken, nil
+	}
+
+	token, err := ExchangeClientCredentials(ctx, ts.so, ts.clientId, ts.clientSecret)
+
+	if err != nil {
+		return "", err
+	}
+
+	margin := tokenRefreshMargin
+	if lifetime := time.Until(token.Expiry); lifetime < 2*margin {
+		margin = lifetime / 2
+	}
+
+	ts.token = token
+	ts.refreshAt = token.Expiry.Add(-margin)
+
+	return token.AccessToken, nil
+}
+
+// invalidate discards the current token if it's still the given one, such as once the API has rejected it.
+func (ts *TokenSource) invalidate(token string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != nil && ts.token.AccessToken == token {
+		ts.token = nil
+	}
+}
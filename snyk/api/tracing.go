@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/lendi-au/terraform-provider-snyk/snyk/api"
+
+// orgPathPattern finds the organization in both v1 (/org/{id}) and REST (/orgs/{id}) paths.
+var orgPathPattern = regexp.MustCompile(`/orgs?/([^/]+)`)
+
+func startRequestSpan(ctx context.Context, req *http.Request) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLPath(req.URL.Path),
+	}
+
+	if match := orgPathPattern.FindStringSubmatch(req.URL.Path); match != nil {
+		attributes = append(attributes, attribute.String("snyk.org_id", match[1]))
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, "HTTP "+req.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+func endRequestSpan(span trace.Span, res *http.Response, err error) {
+	defer span.End()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+
+	if res.StatusCode >= 400 {
+		span.SetStatus(codes.Error, res.Status)
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRequestSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	defer provider.Shutdown(context.Background())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL+"/rest/orgs/abc/targets", nil)
+
+	_, err := doRequest(SnykOptions{}, req, statusError)
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	attributes := map[string]string{}
+	for _, kv := range spans[0].Attributes {
+		attributes[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	if spans[0].Name != "HTTP GET" || spans[0].Status.Code != codes.Error {
+		t.Errorf("unexpected span: %s %v", spans[0].Name, spans[0].Status)
+	}
+
+	if attributes["snyk.org_id"] != "abc" || attributes["http.response.status_code"] != "404" {
+		t.Errorf("unexpected attributes: %v", attributes)
+	}
+}
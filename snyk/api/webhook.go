@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type Webhook struct {
+	Id     string `json:"id,omitempty"`
+	OrgId  string `json:"-"`
+	Url    string `json:"url"`
+	Secret string `json:"secret,omitempty"`
+}
+
+type webhookListing struct {
+	Results []Webhook `json:"results"`
+	Total   int       `json:"total"`
+}
+
+func CreateWebhook(ctx context.Context, so SnykOptions, orgId string, url string, secret string) (*Webhook, error) {
+	path := fmt.Sprintf("/org/%s/webhooks", orgId)
+
+	newHook := Webhook{
+		Url:    url,
+		Secret: secret,
+	}
+
+	body, _ := json.Marshal(newHook)
+
+	res, err := clientDo(ctx, so, "POST", path, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var hook = new(Webhook)
+	err = json.NewDecoder(res.Body).Decode(hook)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hook.OrgId = orgId
+
+	return hook, nil
+}
+
+// ListWebhooks lists the organization's webhooks. Unlike other v1 listings, the webhooks endpoint takes no
+// page parameters and returns every webhook at once, so it isn't read with newV1Iterator. Its total is
+// checked instead, so that a truncated listing fails rather than silently dropping webhooks.
+func ListWebhooks(ctx context.Context, so SnykOptions, orgId string) ([]Webhook, error) {
+	path := fmt.Sprintf("/org/%s/webhooks", orgId)
+
+	res, err := clientDo(ctx, so, "GET", path, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var listing webhookListing
+	err = json.NewDecoder(res.Body).Decode(&listing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if listing.Total != len(listing.Results) {
+		return nil, fmt.Errorf("listing webhooks returned %d of %d webhooks", len(listing.Results), listing.Total)
+	}
+
+	for i := range listing.Results {
+		listing.Results[i].OrgId = orgId
+	}
+
+	return listing.Results, nil
+}
+
+// PingWebhook asks Snyk to send a ping event to the webhook, which fails if the target URL cannot be reached.
+func PingWebhook(ctx context.Context, so SnykOptions, orgId string, id string) error {
+	path := fmt.Sprintf("/org/%s/webhooks/%s/ping", orgId, id)
+
+	res, err := clientDo(ctx, so, "POST", path, nil)
+
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
+
+func DeleteWebhook(ctx context.Context, so SnykOptions, orgId string, id string) error {
+	path := fmt.Sprintf("/org/%s/webhooks/%s", orgId, id)
+
+	_, err := clientDo(ctx, so, "DELETE", path, nil)
+
+	return err
+}
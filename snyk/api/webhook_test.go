@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListWebhooks(t *testing.T) {
+	var body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	so := SnykOptions{ApiKey: "key", Endpoint: server.URL}
+
+	body = `{"results": [{"id": "a", "url": "https://example.com/a"}, {"id": "b", "url": "https://example.com/b"}], "total": 2}`
+	hooks, err := ListWebhooks(context.Background(), so, "org")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(hooks) != 2 || hooks[1].Id != "b" || hooks[1].OrgId != "org" {
+		t.Errorf("unexpected webhooks: %#v", hooks)
+	}
+
+	body = `{"results": [{"id": "a", "url": "https://example.com/a"}], "total": 2}`
+
+	if _, err := ListWebhooks(context.Background(), so, "org"); err == nil {
+		t.Error("expected an error for a listing with fewer webhooks than its total")
+	}
+}
+
+func TestWebhookLifecycle(t *testing.T) {
+	_, so, orgId := fakeOrganization(t)
+	ctx := context.Background()
+
+	hook, err := CreateWebhook(ctx, so, orgId, "https://example.com/hook", "secret")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hook.Id == "" || hook.OrgId != orgId || hook.Url != "https://example.com/hook" {
+		t.Errorf("unexpected webhook: %#v", hook)
+	}
+
+	if err := PingWebhook(ctx, so, orgId, hook.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DeleteWebhook(ctx, so, orgId, hook.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if hooks, err := ListWebhooks(ctx, so, orgId); err != nil || len(hooks) != 0 {
+		t.Errorf("expected no webhooks left, got: %#v, %v", hooks, err)
+	}
+
+	if err := PingWebhook(ctx, so, orgId, hook.Id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound pinging a deleted webhook, got: %v", err)
+	}
+}
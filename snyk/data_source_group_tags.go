@@ -0,0 +1,52 @@
+package snyk
+
+import (
+	"context"
+
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGroupTags() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGroupTagsRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": getGroupIdSchema(false),
+			"tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: getGroupTagSchema(true),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGroupTagsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := resourceGroupOptions(d, m)
+
+	tags, err := api.ListGroupTags(ctx, so)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattened := make([]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		flattened = append(flattened, flattenGroupTag(tag))
+	}
+
+	d.Set("group_id", so.GroupId)
+	d.Set("tags", flattened)
+	d.SetId(so.GroupId)
+
+	return diags
+}
@@ -0,0 +1,123 @@
+package snyk
+
+import (
+	"context"
+	"time"
+
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceIgnores() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIgnoresRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ignores": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issue_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"reason_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ignored_by": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expires": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expired": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIgnoresRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+	orgId := d.Get("organization").(string)
+
+	projects, err := api.ListProjects(ctx, so, orgId)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	now := time.Now()
+	ignores := make([]interface{}, 0)
+
+	for _, project := range projects {
+		projectIgnores, err := api.ListIgnores(ctx, so, orgId, project.Id)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, ignore := range projectIgnores {
+			expires := ""
+			if ignore.Expires != nil {
+				expires = ignore.Expires.Format(time.RFC3339)
+			}
+
+			ignores = append(ignores, map[string]interface{}{
+				"project":      project.Id,
+				"project_name": project.Name,
+				"issue_id":     ignore.IssueId,
+				"path":         ignore.Path,
+				"reason":       ignore.Reason,
+				"reason_type":  ignore.ReasonType,
+				"ignored_by":   ignore.IgnoredBy,
+				"created":      ignore.Created.Format(time.RFC3339),
+				"expires":      expires,
+				"expired":      ignore.Expired(now),
+			})
+		}
+	}
+
+	d.Set("ignores", ignores)
+	d.SetId(orgId)
+
+	return diags
+}
@@ -5,58 +5,106 @@ import (
 
 	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func dataSourceOrganization() *schema.Resource {
-	return &schema.Resource{
-		ReadContext: dataSourceOrganizationRead,
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:     schema.TypeString,
+var _ datasource.DataSource = &organizationDataSource{}
+
+type organizationDataSource struct {
+	so api.SnykOptions
+}
+
+type organizationDataSourceModel struct {
+	Id       types.String   `tfsdk:"id"`
+	Created  types.String   `tfsdk:"created"`
+	GroupId  types.String   `tfsdk:"group_id"`
+	Name     types.String   `tfsdk:"name"`
+	Slug     types.String   `tfsdk:"slug"`
+	Url      types.String   `tfsdk:"url"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func newOrganizationDataSource() datasource.DataSource {
+	return &organizationDataSource{}
+}
+
+func (d *organizationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (d *organizationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
 				Required: true,
 			},
-			"created": {
-				Type:     schema.TypeString,
+			"created": schema.StringAttribute{
+				Computed: true,
+			},
+			"group_id": schema.StringAttribute{
+				Optional: true,
 				Computed: true,
 			},
-			"name": {
-				Type:     schema.TypeString,
+			"name": schema.StringAttribute{
 				Computed: true,
 			},
-			"slug": {
-				Type:     schema.TypeString,
+			"slug": schema.StringAttribute{
 				Computed: true,
 			},
-			"url": {
-				Type:     schema.TypeString,
+			"url": schema.StringAttribute{
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
 	}
 }
 
-func dataSourceOrganizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func (d *organizationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if so, ok := providerOptions(req.ProviderData, &resp.Diagnostics); ok {
+		d.so = so
+	}
+}
 
-	var diags diag.Diagnostics
+func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data organizationDataSourceModel
 
-	so := m.(api.SnykOptions)
-	id := d.Get("id").(string)
+	ctx, span := startSpan(ctx, "data.snyk_organization", "read")
+	defer func() { endFrameworkSpan(span, data.Id.ValueString(), resp.Diagnostics) }()
 
-	org, err := api.GetOrganization(so, id)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
-	if err != nil {
-		return diag.FromErr(err)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	d.Set("id", org.Id)
-	d.Set("created", org.Created.String())
-	d.Set("name", org.Name)
-	d.Set("slug", org.Slug)
-	d.Set("url", org.Url)
+	timeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	so := groupOptions(d.so, data.GroupId)
+	org, err := api.GetOrganization(ctx, so, data.Id.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading organization", err.Error())
+		return
+	}
 
-	d.SetId(org.Id)
+	data.GroupId = types.StringValue(so.GroupId)
+	data.Created = types.StringValue(org.Created.String())
+	data.Name = types.StringValue(org.Name)
+	data.Slug = types.StringValue(org.Slug)
+	data.Url = types.StringValue(org.Url)
 
-	return diags
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,96 @@
+package snyk
+
+import (
+	"context"
+
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTargets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTargetsRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"targets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_private": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"integration_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"integration_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTargetsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+	orgId := d.Get("organization").(string)
+
+	targets, err := api.ListTargets(ctx, so, orgId, d.Get("display_name").(string))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattened := make([]interface{}, 0, len(targets))
+	for _, target := range targets {
+		flattened = append(flattened, map[string]interface{}{
+			"id":               target.Id,
+			"display_name":     target.DisplayName,
+			"url":              target.Url,
+			"is_private":       target.IsPrivate,
+			"created":          target.Created.String(),
+			"integration_id":   target.IntegrationId,
+			"integration_type": target.IntegrationType,
+		})
+	}
+
+	d.Set("targets", flattened)
+	d.SetId(orgId)
+
+	return diags
+}
@@ -0,0 +1,102 @@
+package snyk
+
+import (
+	"context"
+	"time"
+
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &oauthTokenEphemeralResource{}
+
+// oauthTokenEphemeralResource exchanges OAuth client credentials for an access token that's only used for
+// the duration of a run, and never stored in state or plans.
+type oauthTokenEphemeralResource struct {
+	so api.SnykOptions
+}
+
+type oauthTokenModel struct {
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	AccessToken  types.String `tfsdk:"access_token"`
+	TokenType    types.String `tfsdk:"token_type"`
+	Scope        types.String `tfsdk:"scope"`
+	ExpiresAt    types.String `tfsdk:"expires_at"`
+}
+
+func newOAuthTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &oauthTokenEphemeralResource{}
+}
+
+func (e *oauthTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth_token"
+}
+
+func (e *oauthTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"client_id": schema.StringAttribute{
+				Required: true,
+			},
+			"client_secret": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+			},
+			"access_token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"token_type": schema.StringAttribute{
+				Computed: true,
+			},
+			"scope": schema.StringAttribute{
+				Computed: true,
+			},
+			"expires_at": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (e *oauthTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if so, ok := providerOptions(req.ProviderData, &resp.Diagnostics); ok {
+		e.so = so
+	}
+}
+
+func (e *oauthTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data oauthTokenModel
+
+	ctx, span := startSpan(ctx, "ephemeral.snyk_oauth_token", "open")
+	defer func() { endFrameworkSpan(span, "", resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the exchange is authenticated by the configured credentials alone, so the provider's own access token
+	// mustn't be sent with it, or invalidated when the configured credentials are rejected
+	so := e.so
+	so.Tokens = nil
+
+	token, err := api.ExchangeClientCredentials(ctx, so, data.ClientId.ValueString(), data.ClientSecret.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error exchanging client credentials", err.Error())
+		return
+	}
+
+	data.AccessToken = types.StringValue(token.AccessToken)
+	data.TokenType = types.StringValue(token.TokenType)
+	data.Scope = types.StringValue(token.Scope)
+	data.ExpiresAt = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,108 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+// The token is only ever visible to the write-only credential it's passed to, so the test checks what the
+// fake Snyk API was sent.
+func TestAccOAuthTokenEphemeralResource(t *testing.T) {
+	testAccSkipBeforeTerraform(t, "1.11.0")
+
+	server := testAccFakeServer(t)
+
+	if server == nil {
+		t.Skip("client credentials are only known for the fake Snyk API")
+	}
+
+	rOrgName := acctest.RandomWithPrefix(testAccPrefix)
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOAuthToken(rOrgName, fakesnyk.ClientId, fakesnyk.ClientSecret),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFakeIntegrationCredential(server, "snyk_integration.integ_test_integ", "token", "fake-access-token-"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOAuthToken(name string, clientId string, clientSecret string) string {
+	return fmt.Sprintf(`
+	ephemeral "snyk_oauth_token" "test" {
+		client_id = "%s"
+		client_secret = "%s"
+	}
+
+	resource "snyk_organization" "integ_test_org" {
+		name = "%s"
+		deletion_protection = false
+	}
+
+	resource "snyk_integration" "integ_test_integ" {
+		organization = snyk_organization.integ_test_org.id
+		type = "gitlab"
+		credentials {
+			url = "https://gitlab.example.com"
+			token_wo = ephemeral.snyk_oauth_token.test.access_token
+		}
+	}
+	`, clientId, clientSecret, name)
+}
+
+// Credentials the token endpoint rejects mustn't cost the provider its own access token.
+func TestOAuthTokenOpenKeepsProviderToken(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	so := api.SnykOptions{GroupId: fakesnyk.GroupId, Endpoint: server.URL}
+	so.Tokens = api.NewTokenSource(so, fakesnyk.ClientId, fakesnyk.ClientSecret)
+
+	providerToken, err := so.Tokens.Token(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := &oauthTokenEphemeralResource{so: so}
+
+	var schemaResp ephemeral.SchemaResponse
+	e.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"client_id":     tftypes.NewValue(tftypes.String, fakesnyk.ClientId),
+			"client_secret": tftypes.NewValue(tftypes.String, "wrong"),
+			"access_token":  tftypes.NewValue(tftypes.String, nil),
+			"token_type":    tftypes.NewValue(tftypes.String, nil),
+			"scope":         tftypes.NewValue(tftypes.String, nil),
+			"expires_at":    tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+
+	var resp ephemeral.OpenResponse
+	e.Open(ctx, ephemeral.OpenRequest{Config: config}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the exchange to fail for a wrong secret")
+	}
+
+	if token, _ := so.Tokens.Token(ctx); token != providerToken {
+		t.Error("expected the provider's access token to be kept")
+	}
+}
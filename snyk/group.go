@@ -0,0 +1,54 @@
+package snyk
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+// Group scoped resources take an optional group_id, falling back to the provider's group. Nothing they manage
+// can move between groups, so resources replace themselves when it changes.
+func getGroupIdSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: forceNew,
+	}
+}
+
+// resourceGroupOptions points the options at the group_id of a resource or data source, or leaves them in the
+// provider's group when it has none.
+func resourceGroupOptions(d *schema.ResourceData, m interface{}) api.SnykOptions {
+	return m.(api.SnykOptions).InGroup(d.Get("group_id").(string))
+}
+
+// customizeGroupIdDiff plans an unset group_id as the provider's group. Without it, removing an override would
+// keep the computed value from state and leave the resource in the other group.
+func customizeGroupIdDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.GetRawConfig().GetAttr("group_id").IsNull() {
+		return nil
+	}
+
+	groupId := m.(api.SnykOptions).GroupId
+	current := d.Get("group_id").(string)
+
+	// state written before group_id existed has none, and was always in the provider's group
+	if current == groupId || (current == "" && d.Id() != "") {
+		return nil
+	}
+
+	return d.SetNew("group_id", groupId)
+}
+
+// importGroupResource accepts either the resource's ID in the provider's group, or <group id>/<id>.
+func importGroupResource(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if groupId, id, ok := strings.Cut(d.Id(), "/"); ok {
+		d.Set("group_id", groupId)
+		d.SetId(id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,126 @@
+package snyk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func TestGroupIdDiff(t *testing.T) {
+	so := api.SnykOptions{GroupId: "provider-group"}
+
+	r := resourceGroupTags()
+
+	// the SDK only passes the raw config through the prior state, as Terraform does when planning
+	state := func(groupId string, config map[string]interface{}) *terraform.InstanceState {
+		raw, err := json.Marshal(config)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rawConfig, err := ctyjson.Unmarshal(raw, r.CoreConfigSchema().ImpliedType())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if groupId == "-" {
+			return &terraform.InstanceState{RawConfig: rawConfig}
+		}
+
+		return &terraform.InstanceState{
+			ID:        "provider-group",
+			RawConfig: rawConfig,
+			Attributes: map[string]string{
+				"id":               "provider-group",
+				"group_id":         groupId,
+				"delete_unused":    "false",
+				"tag.#":            "1",
+				"tag.0.key":        "team",
+				"tag.0.value":      "platform",
+				"unmanaged_tags.#": "0",
+			},
+		}
+	}
+
+	config := func(groupId string) map[string]interface{} {
+		raw := map[string]interface{}{
+			"tag": []interface{}{map[string]interface{}{"key": "team", "value": "platform"}},
+		}
+
+		if groupId != "" {
+			raw["group_id"] = groupId
+		}
+
+		return raw
+	}
+
+	cases := map[string]struct {
+		state    string
+		config   string
+		expected string
+		replace  bool
+	}{
+		"create in the provider's group": {"-", "", "provider-group", false},
+		"create in another group":        {"-", "other-group", "other-group", false},
+		"unchanged override":             {"other-group", "other-group", "", false},
+		"added override":                 {"provider-group", "other-group", "other-group", true},
+		"removed override":               {"other-group", "", "provider-group", true},
+		"state without group_id":         {"", "", "", false},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := config(c.config)
+			diff, err := r.Diff(context.Background(), state(c.state, raw), terraform.NewResourceConfigRaw(raw), so)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var planned string
+			if diff != nil && diff.Attributes["group_id"] != nil {
+				planned = diff.Attributes["group_id"].New
+			}
+
+			if planned != c.expected {
+				t.Errorf("expected group_id to be planned as %q, got %q", c.expected, planned)
+			}
+
+			// every ForceNew attribute is flagged on create, so replacement only means something for an update
+			if replace := diff != nil && diff.RequiresNew(); c.state != "-" && replace != c.replace {
+				t.Errorf("expected replacement = %t, got %t", c.replace, replace)
+			}
+		})
+	}
+}
+
+func TestImportGroupResource(t *testing.T) {
+	cases := map[string]struct {
+		groupId string
+		id      string
+	}{
+		"policy-id":             {"", "policy-id"},
+		"other-group/policy-id": {"other-group", "policy-id"},
+	}
+
+	for importId, c := range cases {
+		d := resourceLicensePolicy().TestResourceData()
+		d.SetId(importId)
+
+		imported, err := importGroupResource(context.Background(), d, api.SnykOptions{GroupId: "provider-group"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if groupId := imported[0].Get("group_id").(string); groupId != c.groupId || imported[0].Id() != c.id {
+			t.Errorf("%s: expected %q in group %q, got %q in group %q", importId, c.id, c.groupId, imported[0].Id(), groupId)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package snyk
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+// Attachment attributes shared by the group policy resources. A policy applies either to a set of
+// organizations, or to every project tagged with matching project attributes.
+func getPolicyAttachmentSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"organizations": {
+			Type:          schema.TypeSet,
+			Optional:      true,
+			ConflictsWith: []string{"project_attributes"},
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"project_attributes": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"organizations"},
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"criticality": projectAttributeSchema("critical", "high", "medium", "low"),
+					"environment": projectAttributeSchema("frontend", "backend", "internal", "external", "mobile", "saas", "onprem", "hosted", "distributed"),
+					"lifecycle":   projectAttributeSchema("production", "development", "sandbox"),
+				},
+			},
+		},
+	}
+}
+
+func projectAttributeSchema(values ...string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(values, false),
+		},
+	}
+}
+
+func getPolicyAttachmentState(d *schema.ResourceData) api.PolicyAttachments {
+	var attachments api.PolicyAttachments
+
+	if orgs, ok := d.Get("organizations").(*schema.Set); ok && orgs.Len() > 0 {
+		attachments.Organizations = expandStringSet(orgs)
+	}
+
+	if list, ok := d.Get("project_attributes").([]interface{}); ok && len(list) > 0 && list[0] != nil {
+		attrs := list[0].(map[string]interface{})
+		attachments.ProjectAttributes = &api.ProjectAttributes{
+			Criticality: expandStringSet(attrs["criticality"].(*schema.Set)),
+			Environment: expandStringSet(attrs["environment"].(*schema.Set)),
+			Lifecycle:   expandStringSet(attrs["lifecycle"].(*schema.Set)),
+		}
+	}
+
+	return attachments
+}
+
+func setPolicyAttachmentState(attachments api.PolicyAttachments, d *schema.ResourceData) {
+	d.Set("organizations", attachments.Organizations)
+
+	if attachments.ProjectAttributes == nil {
+		d.Set("project_attributes", nil)
+		return
+	}
+
+	d.Set("project_attributes", []interface{}{map[string]interface{}{
+		"criticality": attachments.ProjectAttributes.Criticality,
+		"environment": attachments.ProjectAttributes.Environment,
+		"lifecycle":   attachments.ProjectAttributes.Lifecycle,
+	}})
+}
+
+func expandStringSet(set *schema.Set) []string {
+	values := make([]string, 0, set.Len())
+
+	for _, v := range set.List() {
+		values = append(values, v.(string))
+	}
+
+	return values
+}
@@ -2,11 +2,40 @@ package snyk
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// organizationCacheTTL bounds how stale the group's organization listing can be within a run, as
+// organizations are otherwise each read through a full listing.
+const organizationCacheTTL = time.Minute
+
+// defaultTimeout bounds each resource operation, unless the resource needs longer or sets its own timeouts.
+// Operations are cancelled through their context, which every API call honours.
+const defaultTimeout = 5 * time.Minute
+
+// The client rate limit defaults to a little under the limit Snyk applies to each API token.
+const defaultRequestsPerMinute = 1500
+const defaultBurst = 10
+
+// The provider authenticates with either an API key, or an access token for OAuth client credentials.
+const (
+	authModeApiKey = "api_key"
+	authModeOAuth  = "oauth"
 )
 
 func init() {
@@ -15,47 +44,280 @@ func init() {
 	schema.DescriptionKind = schema.StringMarkdown
 }
 
+// ProviderServer serves the provider over protocol v6, muxing the resources ported to
+// terraform-plugin-framework with those still on terraform-plugin-sdk.
+func ProviderServer(ctx context.Context, version string) (func() tfprotov6.ProviderServer, error) {
+	clients := &clientFactory{}
+
+	sdkServer, err := tf5to6server.UpgradeServer(ctx, func() tfprotov5.ProviderServer {
+		return schema.NewGRPCProviderServer(sdkProvider(version, clients))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	mux, err := tf6muxserver.NewMuxServer(ctx,
+		providerserver.NewProtocol6(newFrameworkProvider(version, clients)),
+		func() tfprotov6.ProviderServer { return sdkServer },
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mux.ProviderServer, nil
+}
+
+// Provider returns the terraform-plugin-sdk half of the provider on its own.
 func Provider(version string) func() *schema.Provider {
 	return func() *schema.Provider {
-		p := &schema.Provider{
-			Schema: map[string]*schema.Schema{
-				"group_id": {
-					Type:        schema.TypeString,
-					Required:    true,
-					DefaultFunc: schema.EnvDefaultFunc("SNYK_API_GROUP", nil),
-				},
-				"api_key": {
-					Type:        schema.TypeString,
-					Required:    true,
-					Sensitive:   true,
-					DefaultFunc: schema.EnvDefaultFunc("SNYK_API_KEY", nil),
-				},
+		return sdkProvider(version, &clientFactory{})
+	}
+}
+
+// sdkProvider has the same provider schema as the framework provider, as the mux server requires.
+func sdkProvider(version string, clients *clientFactory) *schema.Provider {
+	p := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"auth_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{authModeApiKey, authModeOAuth}, false),
+			},
+			"api_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Optional: true,
 			},
-			ResourcesMap: map[string]*schema.Resource{
-				"snyk_organization": resourceOrganization(),
-				"snyk_integration":  resourceIntegration(),
+			"client_secret": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
 			},
-			DataSourcesMap: map[string]*schema.Resource{
-				"snyk_organization": dataSourceOrganization(),
+			"endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
 			},
+			"prevent_organization_deletion": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"requests_per_minute": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"burst": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"snyk_organization_notification_settings": resourceOrganizationNotificationSettings(),
+			"snyk_organization_settings":              resourceOrganizationSettings(),
+			"snyk_group_tags":                         resourceGroupTags(),
+			"snyk_ignore":                             resourceIgnore(),
+			"snyk_license_policy":                     resourceLicensePolicy(),
+			"snyk_security_policy":                    resourceSecurityPolicy(),
+			"snyk_service_account":                    resourceServiceAccount(),
+			"snyk_target":                             resourceTarget(),
+			"snyk_webhook":                            resourceWebhook(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"snyk_ignores":    dataSourceIgnores(),
+			"snyk_group_tags": dataSourceGroupTags(),
+			"snyk_targets":    dataSourceTargets(),
+		},
+	}
+
+	for name, r := range p.ResourcesMap {
+		traceResource(name, r)
+	}
+	for name, r := range p.DataSourcesMap {
+		traceResource("data."+name, r)
+	}
+
+	p.ConfigureContextFunc = configure(version, p, clients)
+
+	return p
+}
+
+func configure(version string, p *schema.Provider, clients *clientFactory) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		config := providerConfig{
+			GroupId:                     d.Get("group_id").(string),
+			AuthMode:                    d.Get("auth_mode").(string),
+			ApiKey:                      d.Get("api_key").(string),
+			ClientId:                    d.Get("client_id").(string),
+			ClientSecret:                d.Get("client_secret").(string),
+			Endpoint:                    d.Get("endpoint").(string),
+			PreventOrganizationDeletion: d.Get("prevent_organization_deletion").(bool),
+			RequestsPerMinute:           d.Get("requests_per_minute").(int),
+			Burst:                       d.Get("burst").(int),
 		}
 
-		p.ConfigureContextFunc = configure(version, p)
+		so, err := clients.options(ctx, config, p.UserAgent("terraform-provider-snyk", version))
 
-		return p
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		return so, nil
 	}
 }
 
-func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		var diags diag.Diagnostics
+// providerConfig is the provider configuration shared by both halves of the provider. Unset values are
+// zero, and take their defaults from the environment or the constants above.
+type providerConfig struct {
+	GroupId                     string
+	AuthMode                    string
+	ApiKey                      string
+	ClientId                    string
+	ClientSecret                string
+	Endpoint                    string
+	PreventOrganizationDeletion bool
+	RequestsPerMinute           int
+	Burst                       int
+}
 
-		config := api.SnykOptions{
-			GroupId:   d.Get("group_id").(string),
-			ApiKey:    d.Get("api_key").(string),
-			UserAgent: p.UserAgent("terraform-provider-snyk", version),
+func (c *providerConfig) resolve() error {
+	if c.GroupId == "" {
+		c.GroupId = os.Getenv("SNYK_API_GROUP")
+	}
+	if c.Endpoint == "" {
+		c.Endpoint = os.Getenv("SNYK_API_ENDPOINT")
+	}
+	if c.Endpoint == "" {
+		c.Endpoint = api.DefaultEndpoint
+	}
+	if c.RequestsPerMinute == 0 {
+		c.RequestsPerMinute = defaultRequestsPerMinute
+	}
+	if c.Burst == 0 {
+		c.Burst = defaultBurst
+	}
+
+	if c.GroupId == "" {
+		return fmt.Errorf("group_id must be configured, or set in env as SNYK_API_GROUP")
+	}
+	if u, err := url.Parse(c.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("endpoint must be an http or https URL, got: %s", c.Endpoint)
+	}
+
+	return c.resolveAuth()
+}
+
+// resolveAuth settles on one way to authenticate, clearing the credentials of the other. Without an
+// auth_mode, it's taken from whichever credentials are configured, then from the environment, preferring
+// api_key when both are there.
+func (c *providerConfig) resolveAuth() error {
+	configuredKey := c.ApiKey != ""
+	configuredClient := c.ClientId != "" || c.ClientSecret != ""
+
+	if configuredKey && configuredClient {
+		return fmt.Errorf("only one of api_key or client_id and client_secret can be configured")
+	}
+
+	if c.AuthMode == "" {
+		switch {
+		case configuredKey:
+			c.AuthMode = authModeApiKey
+		case configuredClient:
+			c.AuthMode = authModeOAuth
+		case os.Getenv("SNYK_API_KEY") == "" && os.Getenv("SNYK_CLIENT_ID") != "":
+			c.AuthMode = authModeOAuth
+		default:
+			c.AuthMode = authModeApiKey
+		}
+	}
+
+	switch c.AuthMode {
+	case authModeApiKey:
+		if configuredClient {
+			return fmt.Errorf("client_id and client_secret can't be configured with auth_mode %q", authModeApiKey)
+		}
+		if c.ApiKey == "" {
+			c.ApiKey = os.Getenv("SNYK_API_KEY")
+		}
+		if c.ApiKey == "" {
+			return fmt.Errorf("api_key must be configured, or set in env as SNYK_API_KEY")
+		}
+		c.ClientId, c.ClientSecret = "", ""
+	case authModeOAuth:
+		if configuredKey {
+			return fmt.Errorf("api_key can't be configured with auth_mode %q", authModeOAuth)
+		}
+		if c.ClientId == "" {
+			c.ClientId = os.Getenv("SNYK_CLIENT_ID")
+		}
+		if c.ClientSecret == "" {
+			c.ClientSecret = os.Getenv("SNYK_CLIENT_SECRET")
 		}
+		if c.ClientId == "" || c.ClientSecret == "" {
+			return fmt.Errorf("client_id and client_secret must be configured, or set in env as SNYK_CLIENT_ID and SNYK_CLIENT_SECRET")
+		}
+		c.ApiKey = ""
+	default:
+		return fmt.Errorf("auth_mode must be one of %q or %q, got: %s", authModeApiKey, authModeOAuth, c.AuthMode)
+	}
+
+	return nil
+}
+
+// clientFactory builds the API client options once for both halves of the provider, so that they share a
+// rate limiter and organization cache.
+type clientFactory struct {
+	mu     sync.Mutex
+	config providerConfig
+	so     *api.SnykOptions
+}
 
-		return config, diags
+func (f *clientFactory) options(ctx context.Context, config providerConfig, userAgent string) (api.SnykOptions, error) {
+	if err := config.resolve(); err != nil {
+		return api.SnykOptions{}, err
 	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.so != nil && f.config == config {
+		return *f.so, nil
+	}
+
+	so := api.SnykOptions{
+		GroupId:   config.GroupId,
+		ApiKey:    config.ApiKey,
+		UserAgent: userAgent,
+		Endpoint:  config.Endpoint,
+
+		PreventOrganizationDeletion: config.PreventOrganizationDeletion,
+
+		Organizations: api.NewOrganizationCache(organizationCacheTTL),
+		RateLimiter:   api.NewRateLimiter(config.RequestsPerMinute, config.Burst),
+	}
+
+	// fetch the first access token up front, so that bad client credentials fail configuration
+	if config.AuthMode == authModeOAuth {
+		so.Tokens = api.NewTokenSource(so, config.ClientId, config.ClientSecret)
+
+		if _, err := so.Tokens.Token(ctx); err != nil {
+			return api.SnykOptions{}, fmt.Errorf("unable to authenticate with client_id and client_secret: %w", err)
+		}
+	}
+
+	f.config = config
+	f.so = &so
+
+	return so, nil
 }
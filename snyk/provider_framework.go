@@ -0,0 +1,164 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+
+// frameworkProvider serves the resources ported to terraform-plugin-framework. Its schema must match the
+// schema of sdkProvider, and both are configured through the same clientFactory.
+type frameworkProvider struct {
+	version string
+	clients *clientFactory
+}
+
+type frameworkProviderModel struct {
+	GroupId                     types.String `tfsdk:"group_id"`
+	AuthMode                    types.String `tfsdk:"auth_mode"`
+	ApiKey                      types.String `tfsdk:"api_key"`
+	ClientId                    types.String `tfsdk:"client_id"`
+	ClientSecret                types.String `tfsdk:"client_secret"`
+	Endpoint                    types.String `tfsdk:"endpoint"`
+	PreventOrganizationDeletion types.Bool   `tfsdk:"prevent_organization_deletion"`
+	RequestsPerMinute           types.Int64  `tfsdk:"requests_per_minute"`
+	Burst                       types.Int64  `tfsdk:"burst"`
+}
+
+func newFrameworkProvider(version string, clients *clientFactory) provider.Provider {
+	return &frameworkProvider{version: version, clients: clients}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "snyk"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"group_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"auth_mode": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf(authModeApiKey, authModeOAuth)},
+			},
+			"api_key": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"client_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"endpoint": schema.StringAttribute{
+				Optional: true,
+			},
+			"prevent_organization_deletion": schema.BoolAttribute{
+				Optional: true,
+			},
+			"requests_per_minute": schema.Int64Attribute{
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"burst": schema.Int64Attribute{
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data frameworkProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := providerConfig{
+		GroupId:                     data.GroupId.ValueString(),
+		AuthMode:                    data.AuthMode.ValueString(),
+		ApiKey:                      data.ApiKey.ValueString(),
+		ClientId:                    data.ClientId.ValueString(),
+		ClientSecret:                data.ClientSecret.ValueString(),
+		Endpoint:                    data.Endpoint.ValueString(),
+		PreventOrganizationDeletion: data.PreventOrganizationDeletion.ValueBool(),
+		RequestsPerMinute:           int(data.RequestsPerMinute.ValueInt64()),
+		Burst:                       int(data.Burst.ValueInt64()),
+	}
+
+	userAgent := fmt.Sprintf("Terraform/%s (+https://www.terraform.io) terraform-provider-snyk/%s", req.TerraformVersion, p.version)
+
+	so, err := p.clients.options(ctx, config, userAgent)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider configuration", err.Error())
+		return
+	}
+
+	resp.ResourceData = so
+	resp.DataSourceData = so
+	resp.EphemeralResourceData = so
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newOrganizationResource,
+		newIntegrationResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newOrganizationDataSource,
+	}
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newOAuthTokenEphemeralResource,
+	}
+}
+
+// providerOptions unwraps the client options the provider hands to its resources and data sources, which are
+// nil until the provider is configured.
+func providerOptions(data any, diags *diag.Diagnostics) (api.SnykOptions, bool) {
+	if data == nil {
+		return api.SnykOptions{}, false
+	}
+
+	so, ok := data.(api.SnykOptions)
+
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("expected api.SnykOptions, got: %T", data))
+	}
+
+	return so, ok
+}
+
+// groupOptions points the options at a resource's own group, or leaves them in the provider's group when the
+// resource has none.
+func groupOptions(so api.SnykOptions, groupId types.String) api.SnykOptions {
+	return so.InGroup(groupId.ValueString())
+}
@@ -1,7 +1,17 @@
 package snyk
 
 import (
+	"context"
+	"errors"
+	"os"
+	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
 )
 
 // Test provider structure - runs the TF internal validation function to ensure provider structure works.
@@ -12,3 +22,173 @@ func TestProvider(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// The mux server refuses to serve when the SDK and framework providers' schemas differ.
+func TestProviderServer(t *testing.T) {
+	ctx := context.Background()
+	server, err := ProviderServer(ctx, "test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, diagnostic := range resp.Diagnostics {
+		t.Errorf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	for _, name := range []string{"snyk_organization", "snyk_integration", "snyk_target"} {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("expected a schema for resource %s", name)
+		}
+	}
+
+	if _, ok := resp.DataSourceSchemas["snyk_organization"]; !ok {
+		t.Error("expected a schema for data source snyk_organization")
+	}
+
+	if _, ok := resp.EphemeralResourceSchemas["snyk_oauth_token"]; !ok {
+		t.Error("expected a schema for ephemeral resource snyk_oauth_token")
+	}
+}
+
+func TestClientFactoryClientCredentials(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	t.Setenv("SNYK_API_KEY", "")
+	ctx := context.Background()
+	config := providerConfig{GroupId: fakesnyk.GroupId, Endpoint: server.URL, ClientId: fakesnyk.ClientId, ClientSecret: fakesnyk.ClientSecret}
+
+	so, err := (&clientFactory{}).options(ctx, config, "test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := api.ListOrganizations(ctx, so); err != nil {
+		t.Errorf("expected requests to be authenticated with an access token, got: %s", err)
+	}
+
+	config.ClientSecret = "wrong"
+
+	if _, err := (&clientFactory{}).options(ctx, config, "test"); !errors.Is(err, api.ErrInvalidAuthn) {
+		t.Errorf("expected ErrInvalidAuthn for a wrong client secret, got: %v", err)
+	}
+}
+
+func TestProviderConfigAuthMode(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      map[string]string
+		config   providerConfig
+		expected string
+		err      string
+	}{
+		{"api key", nil, providerConfig{ApiKey: "key"}, authModeApiKey, ""},
+		{"client credentials", nil, providerConfig{ClientId: "id", ClientSecret: "secret"}, authModeOAuth, ""},
+		{"both configured", nil, providerConfig{ApiKey: "key", ClientId: "id", ClientSecret: "secret"}, "", "only one of"},
+		{"client id alone", nil, providerConfig{ClientId: "id"}, "", "client_id and client_secret must be configured"},
+		{"nothing", nil, providerConfig{}, "", "api_key must be configured"},
+		{"env falls back to api key", map[string]string{"SNYK_API_KEY": "key", "SNYK_CLIENT_ID": "id", "SNYK_CLIENT_SECRET": "secret"}, providerConfig{}, authModeApiKey, ""},
+		{"env client credentials", map[string]string{"SNYK_CLIENT_ID": "id", "SNYK_CLIENT_SECRET": "secret"}, providerConfig{}, authModeOAuth, ""},
+		{"configured over env", map[string]string{"SNYK_API_KEY": "key"}, providerConfig{ClientId: "id", ClientSecret: "secret"}, authModeOAuth, ""},
+		{"selected mode", map[string]string{"SNYK_API_KEY": "key", "SNYK_CLIENT_ID": "id", "SNYK_CLIENT_SECRET": "secret"}, providerConfig{AuthMode: authModeOAuth}, authModeOAuth, ""},
+		{"selected mode conflicts", nil, providerConfig{AuthMode: authModeApiKey, ClientId: "id", ClientSecret: "secret"}, "", "can't be configured with auth_mode"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, key := range []string{"SNYK_API_KEY", "SNYK_CLIENT_ID", "SNYK_CLIENT_SECRET"} {
+				t.Setenv(key, c.env[key])
+			}
+
+			config := c.config
+			config.GroupId = "group"
+			err := config.resolve()
+
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("expected an error containing %q, got: %v", c.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if config.AuthMode != c.expected {
+				t.Errorf("expected auth_mode %q, got %q", c.expected, config.AuthMode)
+			}
+
+			// only the selected mode's credentials are kept
+			if (config.ApiKey != "") != (c.expected == authModeApiKey) || (config.ClientId != "") != (c.expected == authModeOAuth) {
+				t.Errorf("expected only %s credentials, got: %+v", c.expected, config)
+			}
+		})
+	}
+}
+
+// Client credentials from the environment take over from the API key the fake Snyk API is otherwise used with.
+func TestAccProviderClientCredentials(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	if server == nil {
+		t.Skip("client credentials are only known for the fake Snyk API")
+	}
+
+	t.Setenv("SNYK_API_KEY", "")
+	t.Setenv("SNYK_CLIENT_ID", fakesnyk.ClientId)
+	t.Setenv("SNYK_CLIENT_SECRET", fakesnyk.ClientSecret)
+
+	rName := acctest.RandomWithPrefix(testAccPrefix)
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckOrgDestroy(rName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrg(rName),
+				Check:  resource.TestCheckResourceAttr("snyk_organization.org_test_org", "name", rName),
+			},
+		},
+	})
+}
+
+// State written by the terraform-plugin-sdk version of snyk_organization and snyk_integration must plan no
+// changes once the framework versions take over. SNYK_SDK_PROVIDER_VERSION is the version of the SDK provider to
+// apply with, resolved like any other provider, so a local build can be used through a filesystem mirror or a
+// provider_installation override. Against the fake Snyk API, it must be a build that supports SNYK_API_ENDPOINT.
+func TestAccFrameworkStateUpgrade(t *testing.T) {
+	version := os.Getenv("SNYK_SDK_PROVIDER_VERSION")
+
+	if version == "" {
+		t.Skip("env variable SNYK_SDK_PROVIDER_VERSION required to test upgrading state from the SDK provider")
+	}
+
+	rName := acctest.RandomWithPrefix(testAccPrefix)
+	config := testAccIntegration(rName, "bitbucket-cloud", "test_user", "test_password")
+
+	testAccFakeSnyk(t, resource.TestCase{
+		CheckDestroy: testAccCheckOrgDestroy(rName),
+		Steps: []resource.TestStep{
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"snyk": {Source: "registry.terraform.io/lendi-au/snyk", VersionConstraint: version},
+				},
+				Config: config,
+			},
+			{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config:                   config,
+				PlanOnly:                 true,
+			},
+		},
+	})
+}
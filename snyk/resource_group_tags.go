@@ -0,0 +1,206 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+// Snyk creates group tags implicitly when they are applied to a project, so the catalogue can't create tags.
+// Instead it records the allowed tags, surfaces any others in use and can remove the unused ones. Tags Snyk
+// refused to delete are kept in retained_tags, so that only tags that appear later plan another deletion.
+func resourceGroupTags() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupTagsUpdate,
+		ReadContext:   resourceGroupTagsRead,
+		UpdateContext: resourceGroupTagsUpdate,
+		DeleteContext: resourceGroupTagsDelete,
+		CustomizeDiff: customdiff.All(customizeGroupIdDiff, resourceGroupTagsCustomizeDiff),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": getGroupIdSchema(true),
+			"tag": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: getGroupTagSchema(false),
+				},
+			},
+			"delete_unused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"unmanaged_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: getGroupTagSchema(true),
+				},
+			},
+			"retained_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: getGroupTagSchema(true),
+				},
+			},
+		},
+	}
+}
+
+func resourceGroupTagsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := resourceGroupOptions(d, m)
+
+	d.SetId(so.GroupId)
+
+	retained := make([]interface{}, 0)
+
+	if d.Get("delete_unused").(bool) {
+		tags, err := api.ListGroupTags(ctx, so)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		catalogue := getGroupTagCatalogue(d)
+
+		for _, tag := range tags {
+			if catalogue[tag] {
+				continue
+			}
+
+			// without force Snyk refuses to delete tags still applied to a project, which are kept
+			err = api.DeleteGroupTag(ctx, so, tag, false)
+
+			if err != nil {
+				retained = append(retained, flattenGroupTag(tag))
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Unable to delete tag %s:%s", tag.Key, tag.Value),
+					Detail:   fmt.Sprintf("The tag is not in the catalogue but could not be deleted, it is likely still applied to a project: %s", err),
+				})
+			}
+		}
+	}
+
+	d.Set("retained_tags", retained)
+
+	return append(diags, resourceGroupTagsRead(ctx, d, m)...)
+}
+
+func resourceGroupTagsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := resourceGroupOptions(d, m)
+
+	tags, err := api.ListGroupTags(ctx, so)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	catalogue := getGroupTagCatalogue(d)
+	retainedBefore := getGroupTagSet(d.Get("retained_tags").(*schema.Set))
+
+	unmanaged := make([]interface{}, 0)
+	retained := make([]interface{}, 0)
+	for _, tag := range tags {
+		if catalogue[tag] {
+			continue
+		}
+
+		unmanaged = append(unmanaged, flattenGroupTag(tag))
+
+		if retainedBefore[tag] {
+			retained = append(retained, flattenGroupTag(tag))
+		}
+	}
+
+	d.Set("group_id", so.GroupId)
+	d.Set("unmanaged_tags", unmanaged)
+	d.Set("retained_tags", retained)
+
+	return diags
+}
+
+// The tags themselves are left in place on destroy.
+func resourceGroupTagsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
+
+// With delete_unused, unmanaged tags that weren't already retained are drift: the update that deletes them is
+// planned by marking the computed tag sets as changing.
+func resourceGroupTagsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.Get("delete_unused").(bool) {
+		return nil
+	}
+
+	catalogue := getGroupTagSet(d.Get("tag").(*schema.Set))
+	retained := getGroupTagSet(d.Get("retained_tags").(*schema.Set))
+
+	for tag := range getGroupTagSet(d.Get("unmanaged_tags").(*schema.Set)) {
+		if !catalogue[tag] && !retained[tag] {
+			if err := d.SetNewComputed("unmanaged_tags"); err != nil {
+				return err
+			}
+
+			return d.SetNewComputed("retained_tags")
+		}
+	}
+
+	return nil
+}
+
+func getGroupTagSchema(computed bool) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"key": {
+			Type:     schema.TypeString,
+			Required: !computed,
+			Computed: computed,
+		},
+		"value": {
+			Type:     schema.TypeString,
+			Required: !computed,
+			Computed: computed,
+		},
+	}
+}
+
+func getGroupTagCatalogue(d *schema.ResourceData) map[api.GroupTag]bool {
+	return getGroupTagSet(d.Get("tag").(*schema.Set))
+}
+
+func getGroupTagSet(set *schema.Set) map[api.GroupTag]bool {
+	tags := make(map[api.GroupTag]bool)
+
+	for _, v := range set.List() {
+		tag := v.(map[string]interface{})
+		tags[api.GroupTag{Key: tag["key"].(string), Value: tag["value"].(string)}] = true
+	}
+
+	return tags
+}
+
+func flattenGroupTag(tag api.GroupTag) map[string]interface{} {
+	return map[string]interface{}{
+		"key":   tag.Key,
+		"value": tag.Value,
+	}
+}
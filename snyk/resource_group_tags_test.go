@@ -0,0 +1,120 @@
+package snyk
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+)
+
+func TestAccGroupTags(t *testing.T) {
+	server := testAccFakeServer(t)
+	groupId := os.Getenv("SNYK_API_GROUP")
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupTags(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_group_tags.tags_test", "tag.#", "2"),
+					resource.TestCheckResourceAttr("snyk_group_tags.tags_test", "group_id", groupId),
+					resource.TestCheckResourceAttrSet("snyk_group_tags.tags_test", "unmanaged_tags.#"),
+					resource.TestCheckResourceAttrSet("data.snyk_group_tags.tags_test", "tags.#"),
+					resource.TestCheckResourceAttr("data.snyk_group_tags.tags_test", "group_id", groupId),
+				),
+			},
+		},
+	})
+}
+
+// Unused tags that show up after an apply are drift, and the next apply deletes them. Tags still applied to a
+// project are retained, and don't plan another deletion.
+func TestAccGroupTagsDeleteUnused(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	if server == nil {
+		t.Skip("adds tags to the group behind Terraform's back, which only the fake Snyk API allows")
+	}
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					server.AddGroupTag(fakesnyk.GroupId, "team", "platform", true)
+					server.AddGroupTag(fakesnyk.GroupId, "team", "legacy", true)
+					server.AddGroupTag(fakesnyk.GroupId, "stale", "first", false)
+				},
+				Config: testAccGroupTagsDeleteUnused(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_group_tags.tags_test", "unmanaged_tags.#", "1"),
+					resource.TestCheckResourceAttr("snyk_group_tags.tags_test", "retained_tags.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("snyk_group_tags.tags_test", "retained_tags.*", map[string]string{"key": "team", "value": "legacy"}),
+					testAccCheckGroupTags(server, "team:platform", "team:legacy"),
+				),
+			},
+			{
+				PreConfig: func() { server.AddGroupTag(fakesnyk.GroupId, "stale", "second", false) },
+				Config:    testAccGroupTagsDeleteUnused(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_group_tags.tags_test", "retained_tags.#", "1"),
+					testAccCheckGroupTags(server, "team:platform", "team:legacy"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGroupTags(server *fakesnyk.Server, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tags := server.GroupTags(fakesnyk.GroupId)
+
+		found := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			found = append(found, tag.Key+":"+tag.Value)
+		}
+
+		if fmt.Sprint(found) != fmt.Sprint(expected) {
+			return fmt.Errorf("expected group tags %v, got %v", expected, found)
+		}
+
+		return nil
+	}
+}
+
+func testAccGroupTags() string {
+	return `
+	resource "snyk_group_tags" "tags_test" {
+		tag {
+			key   = "team"
+			value = "platform"
+		}
+
+		tag {
+			key   = "team"
+			value = "payments"
+		}
+	}
+
+	data "snyk_group_tags" "tags_test" {
+		depends_on = [snyk_group_tags.tags_test]
+	}
+	`
+}
+
+func testAccGroupTagsDeleteUnused() string {
+	return `
+	resource "snyk_group_tags" "tags_test" {
+		delete_unused = true
+
+		tag {
+			key   = "team"
+			value = "platform"
+		}
+	}
+	`
+}
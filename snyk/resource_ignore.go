@@ -0,0 +1,246 @@
+package snyk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func resourceIgnore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIgnoreCreate,
+		ReadContext:   resourceIgnoreRead,
+		UpdateContext: resourceIgnoreUpdate,
+		DeleteContext: resourceIgnoreDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIgnoreImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"issue_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "*",
+			},
+			"reason": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"reason_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"not-vulnerable", "wont-fix", "temporary-ignore"}, false),
+			},
+			"expires": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTimes,
+			},
+			"expired": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ignored_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceIgnoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := m.(api.SnykOptions)
+
+	ignore, err := getIgnoreState(d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = api.CreateIgnore(ctx, so, ignore)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ignoreId(ignore.OrgId, ignore.ProjectId, ignore.IssueId, ignore.Path))
+
+	return resourceIgnoreRead(ctx, d, m)
+}
+
+func resourceIgnoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+
+	orgId := d.Get("organization").(string)
+	projectId := d.Get("project").(string)
+	issueId := d.Get("issue_id").(string)
+	ignorePath := d.Get("path").(string)
+
+	ignore, err := api.GetIgnore(ctx, so, orgId, projectId, issueId, ignorePath)
+
+	if errors.Is(err, api.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// IDs written before the path was part of them don't tell ignores on the same issue apart
+	d.SetId(ignoreId(orgId, projectId, issueId, ignorePath))
+
+	d.Set("reason", ignore.Reason)
+	d.Set("reason_type", ignore.ReasonType)
+	d.Set("created", ignore.Created.Format(time.RFC3339))
+	d.Set("ignored_by", ignore.IgnoredBy)
+	d.Set("expired", ignore.Expired(time.Now()))
+
+	if ignore.Expires != nil {
+		d.Set("expires", ignore.Expires.Format(time.RFC3339))
+	} else {
+		d.Set("expires", "")
+	}
+
+	return diags
+}
+
+func resourceIgnoreUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := m.(api.SnykOptions)
+
+	ignore, err := getIgnoreState(d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = api.UpdateIgnore(ctx, so, ignore)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceIgnoreRead(ctx, d, m)
+}
+
+func resourceIgnoreDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+
+	orgId := d.Get("organization").(string)
+	projectId := d.Get("project").(string)
+	issueId := d.Get("issue_id").(string)
+	ignorePath := d.Get("path").(string)
+
+	err := api.DeleteIgnore(ctx, so, orgId, projectId, issueId, ignorePath)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// Ignores are imported as "<organization id>/<project id>/<issue id>", with an optional "/<path>" suffix for
+// ignores that don't apply to every path.
+func resourceIgnoreImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 4)
+
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <organization id>/<project id>/<issue id>[/<path>]", d.Id())
+	}
+
+	ignorePath := "*"
+	if len(parts) == 4 {
+		ignorePath = parts[3]
+	}
+
+	d.Set("organization", parts[0])
+	d.Set("project", parts[1])
+	d.Set("issue_id", parts[2])
+	d.Set("path", ignorePath)
+	d.SetId(ignoreId(parts[0], parts[1], parts[2], ignorePath))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// An issue can have an ignore for each path, so the path is part of the ID.
+func ignoreId(orgId string, projectId string, issueId string, ignorePath string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", orgId, projectId, issueId, ignorePath)
+}
+
+func getIgnoreState(d *schema.ResourceData) (api.Ignore, error) {
+	ignore := api.Ignore{
+		OrgId:      d.Get("organization").(string),
+		ProjectId:  d.Get("project").(string),
+		IssueId:    d.Get("issue_id").(string),
+		Path:       d.Get("path").(string),
+		Reason:     d.Get("reason").(string),
+		ReasonType: d.Get("reason_type").(string),
+	}
+
+	if v := d.Get("expires").(string); v != "" {
+		expires, err := time.Parse(time.RFC3339, v)
+
+		if err != nil {
+			return api.Ignore{}, err
+		}
+
+		ignore.Expires = &expires
+	}
+
+	return ignore, nil
+}
+
+// Snyk returns timestamps with millisecond precision in UTC, so compare the instants rather than the strings.
+func suppressEquivalentTimes(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+
+	return oldTime.Equal(newTime)
+}
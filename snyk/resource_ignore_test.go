@@ -0,0 +1,166 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func TestAccIgnore(t *testing.T) {
+	orgId := os.Getenv("SNYK_IGNORE_ORG")
+	projectId := os.Getenv("SNYK_IGNORE_PROJECT")
+	issueId := os.Getenv("SNYK_IGNORE_ISSUE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if orgId == "" || projectId == "" || issueId == "" {
+				t.Skip("env variables SNYK_IGNORE_ORG, SNYK_IGNORE_PROJECT and SNYK_IGNORE_ISSUE required for ignore acceptance tests")
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckIgnoreDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIgnore(orgId, projectId, issueId, "2000-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_ignore.ignore_test", "reason_type", "temporary-ignore"),
+					resource.TestCheckResourceAttr("snyk_ignore.ignore_test", "expired", "true"),
+					resource.TestCheckResourceAttrSet("data.snyk_ignores.ignores_test", "ignores.#"),
+				),
+			},
+			{
+				Config: testAccIgnore(orgId, projectId, issueId, "2999-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_ignore.ignore_test", "expired", "false"),
+				),
+			},
+			{
+				ResourceName:      "snyk_ignore.ignore_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Ignores on the same issue with different paths are separate rules in Snyk, which mustn't overwrite or
+// delete each other.
+func TestAccIgnorePaths(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	if server == nil {
+		t.Skip("runs against the fake Snyk API only; TestAccIgnore covers the real API")
+	}
+
+	rules := func(expected map[string]string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			orgId := s.RootModule().Resources["snyk_organization.ignore_test"].Primary.ID
+			found := map[string]string{}
+
+			for _, ignore := range server.Ignores(orgId, "project", "issue") {
+				found[ignore.Path] = ignore.Reason
+			}
+
+			if fmt.Sprint(found) != fmt.Sprint(expected) {
+				return fmt.Errorf("expected ignore rules %v, got %v", expected, found)
+			}
+
+			return nil
+		}
+	}
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckIgnoreDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIgnorePaths("first", true),
+				Check:  rules(map[string]string{"a@1.0.0 > b@2.0.0": "first", "c@1.0.0": "second"}),
+			},
+			{
+				Config: testAccIgnorePaths("updated", false),
+				Check:  rules(map[string]string{"a@1.0.0 > b@2.0.0": "updated"}),
+			},
+			{
+				ResourceName:      "snyk_ignore.first",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIgnorePaths(reason string, second bool) string {
+	config := fmt.Sprintf(`
+	resource "snyk_organization" "ignore_test" {
+		name                = "%s-ignore"
+		deletion_protection = false
+	}
+
+	resource "snyk_ignore" "first" {
+		organization = snyk_organization.ignore_test.id
+		project      = "project"
+		issue_id     = "issue"
+		path         = "a@1.0.0 > b@2.0.0"
+		reason       = "%s"
+		reason_type  = "wont-fix"
+	}
+	`, testAccPrefix, reason)
+
+	if second {
+		config += `
+	resource "snyk_ignore" "second" {
+		organization = snyk_organization.ignore_test.id
+		project      = "project"
+		issue_id     = "issue"
+		path         = "c@1.0.0"
+		reason       = "second"
+		reason_type  = "wont-fix"
+	}
+	`
+	}
+
+	return config
+}
+
+func testAccIgnore(orgId string, projectId string, issueId string, expires string) string {
+	return fmt.Sprintf(`
+	resource "snyk_ignore" "ignore_test" {
+		organization = "%s"
+		project      = "%s"
+		issue_id     = "%s"
+		reason       = "terraform acceptance test"
+		reason_type  = "temporary-ignore"
+		expires      = "%s"
+	}
+
+	data "snyk_ignores" "ignores_test" {
+		organization = snyk_ignore.ignore_test.organization
+	}
+	`, orgId, projectId, issueId, expires)
+}
+
+func testAccCheckIgnoreDestroy(s *terraform.State) error {
+	so := testAccOptions()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "snyk_ignore" {
+			continue
+		}
+
+		attrs := rs.Primary.Attributes
+		_, err := api.GetIgnore(context.Background(), so, attrs["organization"], attrs["project"], attrs["issue_id"], attrs["path"])
+
+		if err == nil {
+			return fmt.Errorf("ignore %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
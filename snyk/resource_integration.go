@@ -4,226 +4,352 @@ import (
 	"context"
 	"errors"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
 )
 
-func resourceIntegration() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceIntegrationCreate,
-		ReadContext:   resourceIntegrationRead,
-		UpdateContext: resourceIntegrationUpdate,
-		DeleteContext: resourceIntegrationDelete,
-		Schema: map[string]*schema.Schema{
-			"organization": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+var _ resource.Resource = &integrationResource{}
+
+type integrationResource struct {
+	so api.SnykOptions
+}
+
+type integrationModel struct {
+	Id                 types.String                  `tfsdk:"id"`
+	Organization       types.String                  `tfsdk:"organization"`
+	Type               types.String                  `tfsdk:"type"`
+	CredentialsVersion types.Int64                   `tfsdk:"credentials_version"`
+	Credentials        []integrationCredentialsModel `tfsdk:"credentials"`
+	Timeouts           timeouts.Value                `tfsdk:"timeouts"`
+}
+
+type integrationCredentialsModel struct {
+	Username     types.String `tfsdk:"username"`
+	Password     types.String `tfsdk:"password"`
+	PasswordWO   types.String `tfsdk:"password_wo"`
+	RegistryBase types.String `tfsdk:"registry_base"`
+	Url          types.String `tfsdk:"url"`
+	Token        types.String `tfsdk:"token"`
+	TokenWO      types.String `tfsdk:"token_wo"`
+	Region       types.String `tfsdk:"region"`
+	RoleArn      types.String `tfsdk:"role_arn"`
+}
+
+func newIntegrationResource() resource.Resource {
+	return &integrationResource{}
+}
+
+func (r *integrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_integration"
+}
+
+func (r *integrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"organization": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"type": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
-			"type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+			"credentials_version": schema.Int64Attribute{
+				Optional: true,
 			},
-			"credentials": {
-				Type:     schema.TypeList,
-				Required: true,
-				MinItems: 1,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: getCredentialSchema(),
+		},
+		Blocks: map[string]schema.Block{
+			"credentials": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: getCredentialSchema(),
+				},
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					listvalidator.SizeBetween(1, 1),
 				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
-func resourceIntegrationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	var err error
+func (r *integrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if so, ok := providerOptions(req.ProviderData, &resp.Diagnostics); ok {
+		r.so = so
+	}
+}
 
-	so := m.(api.SnykOptions)
+func (r *integrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data integrationModel
 
-	orgId := d.Get("organization").(string)
-	intType := d.Get("type").(string)
-	credentials, err := getCredentialState(d)
+	ctx, span := startSpan(ctx, "snyk_integration", "create")
+	defer func() { endFrameworkSpan(span, data.Organization.ValueString(), resp.Diagnostics) }()
 
-	if err != nil {
-		return diag.FromErr(err)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	exists, err := api.IntegrationExists(so, orgId, intType)
+	timeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	orgId := data.Organization.ValueString()
+	intType := data.Type.ValueString()
+	credentials := configuredCredentials(ctx, req.Config, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, err := api.IntegrationExists(ctx, r.so, orgId, intType)
 
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error reading integration", err.Error())
+		return
 	}
 
 	var integration *api.Integration
 	if !exists { // if integration not found, create it
-		integration, err = api.CreateIntegration(so, orgId, intType, credentials)
-
-		if err != nil {
-			return diag.FromErr(err)
-		}
+		integration, err = api.CreateIntegration(ctx, r.so, orgId, intType, credentials)
 	} else { // otherwise, reactivate credentials
-		integration, err = api.UpdateIntegration(so, orgId, intType, credentials)
+		integration, err = api.UpdateIntegration(ctx, r.so, orgId, intType, credentials)
+	}
 
-		if err != nil {
-			return diag.FromErr(err)
-		}
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating integration", err.Error())
+		return
 	}
 
-	d.SetId(integration.Id)
-	d.Set("organization", integration.OrgId)
-	d.Set("type", integration.Type)
-	setCredentialState(integration.Credentials, d)
+	data.Id = types.StringValue(integration.Id)
 
-	return diags
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func resourceIntegrationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	so := m.(api.SnykOptions)
+// Snyk never returns an integration's credentials, so they're kept as configured.
+func (r *integrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data integrationModel
+
+	ctx, span := startSpan(ctx, "snyk_integration", "read")
+	defer func() { endFrameworkSpan(span, data.Organization.ValueString(), resp.Diagnostics) }()
 
-	orgId := d.Get("organization").(string)
-	intType := d.Get("type").(string)
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	integration, err := api.GetIntegration(so, orgId, intType)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	integration, err := api.GetIntegration(ctx, r.so, data.Organization.ValueString(), data.Type.ValueString())
+
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error reading integration", err.Error())
+		return
 	}
-	d.SetId(integration.Id)
-	d.Set("organization", integration.OrgId)
-	d.Set("type", integration.Type)
 
-	return diags
+	data.Id = types.StringValue(integration.Id)
+
+	// state written by the SDK version of this resource holds empty strings for unset credentials
+	for i := range data.Credentials {
+		data.Credentials[i].nullEmpty()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func resourceIntegrationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	so := m.(api.SnykOptions)
+func (r *integrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data integrationModel
 
-	orgId := d.Get("organization").(string)
-	intType := d.Get("type").(string)
-	credentials, err := getCredentialState(d)
+	ctx, span := startSpan(ctx, "snyk_integration", "update")
+	defer func() { endFrameworkSpan(span, data.Organization.ValueString(), resp.Diagnostics) }()
 
-	if err != nil {
-		return diag.FromErr(err)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	integration, err := api.UpdateIntegration(so, orgId, intType, credentials)
+	timeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
 
-	if err != nil {
-		return diag.FromErr(err)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	setCredentialState(integration.Credentials, d)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	credentials := configuredCredentials(ctx, req.Config, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	return diags
+	_, err := api.UpdateIntegration(ctx, r.so, data.Organization.ValueString(), data.Type.ValueString(), credentials)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating integration", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func resourceIntegrationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+func (r *integrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data integrationModel
 
-	so := m.(api.SnykOptions)
+	ctx, span := startSpan(ctx, "snyk_integration", "delete")
+	defer func() { endFrameworkSpan(span, data.Organization.ValueString(), resp.Diagnostics) }()
 
-	orgId := d.Get("organization").(string)
-	intType := d.Get("type").(string)
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	err := api.DeleteIntegration(so, orgId, intType)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if err != nil {
-		return diag.FromErr(err)
+	timeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	d.SetId("")
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	return diags
+	err := api.DeleteIntegration(ctx, r.so, data.Organization.ValueString(), data.Type.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting integration", err.Error())
+	}
 }
 
-func getCredentialSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"username": {
-			Type:     schema.TypeString,
+func getCredentialSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"username": schema.StringAttribute{
 			Optional: true,
 		},
-		"password": {
-			Type:      schema.TypeString,
+		"password": schema.StringAttribute{
 			Optional:  true,
 			Sensitive: true,
 		},
-		"registry_base": {
-			Type:     schema.TypeString,
+		"password_wo": schema.StringAttribute{
+			Optional:   true,
+			Sensitive:  true,
+			WriteOnly:  true,
+			Validators: []validator.String{stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("password"))},
+		},
+		"registry_base": schema.StringAttribute{
 			Optional: true,
 		},
-		"url": {
-			Type:     schema.TypeString,
+		"url": schema.StringAttribute{
 			Optional: true,
 		},
-		"token": {
-			Type:      schema.TypeString,
+		"token": schema.StringAttribute{
 			Optional:  true,
 			Sensitive: true,
 		},
-		"region": {
-			Type:     schema.TypeString,
+		"token_wo": schema.StringAttribute{
+			Optional:   true,
+			Sensitive:  true,
+			WriteOnly:  true,
+			Validators: []validator.String{stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("token"))},
+		},
+		"region": schema.StringAttribute{
 			Optional: true,
 		},
-		"role_arn": {
-			Type:     schema.TypeString,
+		"role_arn": schema.StringAttribute{
 			Optional: true,
 		},
 	}
 }
 
-func getCredentialState(d *schema.ResourceData) (api.IntegrationCredentials, error) {
-	if credList, ok := d.Get("credentials").([]interface{}); ok {
-		creds := credList[0].(map[string]interface{})
-		return api.IntegrationCredentials{
-			Username:     creds["username"].(string),
-			Password:     creds["password"].(string),
-			RegistryBase: creds["registry_base"].(string),
-			Url:          creds["url"].(string),
-			Token:        creds["token"].(string),
-			Region:       creds["region"].(string),
-			RoleArn:      creds["role_arn"].(string),
-		}, nil
-	}
-
-	return api.IntegrationCredentials{}, errors.New("unable to fetch credentials from state")
-}
-
-func setCredentialState(creds api.IntegrationCredentials, d *schema.ResourceData) {
-
-	stateList := make([]interface{}, 1)
+// password_wo and token_wo take the place of password and token, when set.
+func (data integrationModel) credentials() api.IntegrationCredentials {
+	if len(data.Credentials) == 0 {
+		return api.IntegrationCredentials{}
+	}
 
-	stateMap := make(map[string]interface{})
+	creds := data.Credentials[0]
 
-	if creds.Username != "" {
-		stateMap["username"] = creds.Username
-	}
-	if creds.Password != "" {
-		stateMap["password"] = creds.Password
-	}
-	if creds.RegistryBase != "" {
-		stateMap["registry_base"] = creds.RegistryBase
-	}
-	if creds.Url != "" {
-		stateMap["url"] = creds.Url
+	password := creds.Password.ValueString()
+	if !creds.PasswordWO.IsNull() {
+		password = creds.PasswordWO.ValueString()
 	}
-	if creds.Token != "" {
-		stateMap["token"] = creds.Token
-	}
-	if creds.Region != "" {
-		stateMap["region"] = creds.Region
+
+	token := creds.Token.ValueString()
+	if !creds.TokenWO.IsNull() {
+		token = creds.TokenWO.ValueString()
 	}
-	if creds.RoleArn != "" {
-		stateMap["role_arn"] = creds.RoleArn
+
+	return api.IntegrationCredentials{
+		Username:     creds.Username.ValueString(),
+		Password:     password,
+		RegistryBase: creds.RegistryBase.ValueString(),
+		Url:          creds.Url.ValueString(),
+		Token:        token,
+		Region:       creds.Region.ValueString(),
+		RoleArn:      creds.RoleArn.ValueString(),
 	}
+}
+
+// Credentials are read from the configuration rather than the plan, as write-only values are only ever
+// in the configuration.
+func configuredCredentials(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) api.IntegrationCredentials {
+	var data integrationModel
 
-	stateList[0] = stateMap
+	diags.Append(config.Get(ctx, &data)...)
 
-	d.Set("credentials", stateList)
+	return data.credentials()
+}
+
+func (creds *integrationCredentialsModel) nullEmpty() {
+	for _, value := range []*types.String{
+		&creds.Username, &creds.Password, &creds.RegistryBase, &creds.Url, &creds.Token, &creds.Region, &creds.RoleArn,
+	} {
+		if !value.IsNull() && !value.IsUnknown() && value.ValueString() == "" {
+			*value = types.StringNull()
+		}
+	}
 }
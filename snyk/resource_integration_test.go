@@ -1,12 +1,22 @@
 package snyk
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
 	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
 )
 
@@ -17,14 +27,13 @@ func TestAccIntegration(t *testing.T) {
 	// collisions from multiple concurrent tests.
 	// the acctest package includes many helpers such as RandStringFromCharSet
 	// See https://pkg.go.dev/github.com/hashicorp/terraform-plugin-sdk/helper/acctest
-	rOrgName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	rOrgName := acctest.RandomWithPrefix(testAccPrefix)
 	intType := "bitbucket-cloud"
 	username := "test_user"
 	password := "test_pass"
 
-	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+	testAccFakeSnyk(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				// use a dynamic configuration with the random name from above
@@ -43,11 +52,106 @@ func TestAccIntegration(t *testing.T) {
 	})
 }
 
+func TestAccIntegrationWriteOnlyCredentials(t *testing.T) {
+	testAccSkipBeforeTerraform(t, "1.11.0")
+
+	rOrgName := acctest.RandomWithPrefix(testAccPrefix)
+	server := testAccFakeServer(t)
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIntegrationWriteOnly(rOrgName, "first-secret", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("snyk_integration.integ_test_integ", "credentials.0.password"),
+					resource.TestCheckNoResourceAttr("snyk_integration.integ_test_integ", "credentials.0.password_wo"),
+					testAccCheckNoSecretInState("snyk_integration.integ_test_integ", "first-secret"),
+					testAccCheckFakeIntegrationCredential(server, "snyk_integration.integ_test_integ", "password", "first-secret"),
+				),
+			},
+			{
+				// only a new credentials_version sends the new secret
+				Config: testAccIntegrationWriteOnly(rOrgName, "second-secret", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_integration.integ_test_integ", "credentials_version", "2"),
+					testAccCheckNoSecretInState("snyk_integration.integ_test_integ", "second-secret"),
+					testAccCheckFakeIntegrationCredential(server, "snyk_integration.integ_test_integ", "password", "second-secret"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIntegrationWriteOnly(name string, password string, version int) string {
+	return fmt.Sprintf(`
+	resource "snyk_organization" "integ_test_org" {
+		name = "%s"
+		deletion_protection = false
+	}
+
+	resource "snyk_integration" "integ_test_integ" {
+		organization = snyk_organization.integ_test_org.id
+		type = "bitbucket-cloud"
+		credentials_version = %d
+		credentials {
+			username = "test_user"
+			password_wo = "%s"
+		}
+	}
+	`, name, version, password)
+}
+
+func testAccCheckNoSecretInState(n string, secret string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		for key, value := range rs.Primary.Attributes {
+			if value == secret {
+				return fmt.Errorf("secret stored in state as %s", key)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckFakeIntegrationCredential checks the start of a credential the fake Snyk API was sent, which the
+// real API doesn't return.
+func testAccCheckFakeIntegrationCredential(server *fakesnyk.Server, n string, key string, prefix string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if server == nil {
+			return nil
+		}
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		integration := server.Integration(rs.Primary.Attributes["organization"], rs.Primary.Attributes["type"])
+
+		if integration == nil {
+			return fmt.Errorf("integration %s not found", rs.Primary.ID)
+		}
+
+		if !strings.HasPrefix(integration.Credentials[key], prefix) {
+			return fmt.Errorf("expected %s starting with %q to be sent, got: %q", key, prefix, integration.Credentials[key])
+		}
+
+		return nil
+	}
+}
+
 // TODO: environment variables so you can use legit credentials
 func testAccIntegration(name string, intType string, username string, password string) string {
 	return fmt.Sprintf(`
 	resource "snyk_organization" "integ_test_org" {
 		name = "%s"
+		deletion_protection = false
 	}
 	
 	resource "snyk_integration" "integ_test_integ" {
@@ -80,11 +184,11 @@ func testAccCheckIntegrationExists(n string, i *api.Integration) resource.TestCh
 		}
 
 		// retrieve the client options from the test setup
-		so := testAccProviders["snyk"].Meta().(api.SnykOptions)
+		so := testAccOptions()
 		intType := rs.Primary.Attributes["type"]
 		orgId := rs.Primary.Attributes["organization"]
 
-		res, err := api.GetIntegration(so, orgId, intType)
+		res, err := api.GetIntegration(context.Background(), so, orgId, intType)
 
 		if err != nil {
 			return err
@@ -96,3 +200,141 @@ func testAccCheckIntegrationExists(n string, i *api.Integration) resource.TestCh
 		return nil
 	}
 }
+
+// testIntegrationValue builds a snyk_integration object with the given attributes set, and every other
+// attribute null.
+func testIntegrationValue(t *testing.T, attributes map[string]interface{}) (fwschema.Schema, tftypes.Value) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	newIntegrationResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	for name, value := range attributes {
+		if diags := plan.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatal(diags)
+		}
+	}
+
+	return schemaResp.Schema, plan.Raw
+}
+
+func TestConfiguredCredentials(t *testing.T) {
+	cases := []struct {
+		credentials integrationCredentialsModel
+		expected    api.IntegrationCredentials
+	}{
+		{
+			integrationCredentialsModel{Username: types.StringValue("user"), Password: types.StringValue("password")},
+			api.IntegrationCredentials{Username: "user", Password: "password"},
+		},
+		{
+			integrationCredentialsModel{Username: types.StringValue("user"), PasswordWO: types.StringValue("password-wo")},
+			api.IntegrationCredentials{Username: "user", Password: "password-wo"},
+		},
+		{
+			integrationCredentialsModel{TokenWO: types.StringValue("token-wo")},
+			api.IntegrationCredentials{Token: "token-wo"},
+		},
+	}
+
+	for _, c := range cases {
+		s, raw := testIntegrationValue(t, map[string]interface{}{
+			"organization": "org",
+			"type":         "bitbucket-cloud",
+			"credentials":  []integrationCredentialsModel{c.credentials.withNulls()},
+		})
+
+		var diags diag.Diagnostics
+		creds := configuredCredentials(context.Background(), tfsdk.Config{Schema: s, Raw: raw}, &diags)
+
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+
+		if creds != c.expected {
+			t.Errorf("expected %#v to be sent, got %#v", c.expected, creds)
+		}
+	}
+}
+
+// Bumping credentials_version resends the write-only credentials, which are only in the configuration.
+func TestIntegrationCredentialsVersionResend(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	so := api.SnykOptions{GroupId: fakesnyk.GroupId, ApiKey: fakesnyk.ApiKey, Endpoint: server.URL}
+
+	org, err := api.CreateOrganization(ctx, so, testAccPrefix+"-credentials-version")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	integration, err := api.CreateIntegration(ctx, so, org.Id, "bitbucket-cloud", api.IntegrationCredentials{Username: "user", Password: "old"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := func(version int64, password types.String) (fwschema.Schema, tftypes.Value) {
+		return testIntegrationValue(t, map[string]interface{}{
+			"id":                  integration.Id,
+			"organization":        org.Id,
+			"type":                "bitbucket-cloud",
+			"credentials_version": version,
+			"credentials": []integrationCredentialsModel{
+				integrationCredentialsModel{Username: types.StringValue("user"), PasswordWO: password}.withNulls(),
+			},
+		})
+	}
+
+	s, state := value(1, types.StringNull())
+	_, plan := value(2, types.StringNull())
+	_, config := value(2, types.StringValue("new"))
+
+	resp := fwresource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: state}}
+	(&integrationResource{so: so}).Update(ctx, fwresource.UpdateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: config},
+		Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+		State:  tfsdk.State{Schema: s, Raw: state},
+	}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	if sent := server.Integration(org.Id, "bitbucket-cloud").Credentials["password"]; sent != "new" {
+		t.Errorf("expected the write-only password to be sent, got %q", sent)
+	}
+
+	var data integrationModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	if !data.Credentials[0].PasswordWO.IsNull() {
+		t.Error("expected the write-only password not to be stored in state")
+	}
+}
+
+// withNulls sets the attributes the test left unset to null, rather than the zero value Terraform rejects.
+func (creds integrationCredentialsModel) withNulls() integrationCredentialsModel {
+	for _, value := range []*types.String{
+		&creds.Username, &creds.Password, &creds.PasswordWO, &creds.RegistryBase, &creds.Url, &creds.Token,
+		&creds.TokenWO, &creds.Region, &creds.RoleArn,
+	} {
+		if *value == (types.String{}) {
+			*value = types.StringNull()
+		}
+	}
+
+	return creds
+}
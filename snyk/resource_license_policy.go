@@ -0,0 +1,193 @@
+package snyk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func resourceLicensePolicy() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"group_id": getGroupIdSchema(true),
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"license": {
+			Type:     schema.TypeSet,
+			Required: true,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"spdx_id": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(spdxLicenseIds, false),
+					},
+					"severity": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"none", "low", "medium", "high"}, false),
+					},
+					"instructions": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	for k, v := range getPolicyAttachmentSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceLicensePolicyCreate,
+		ReadContext:   resourceLicensePolicyRead,
+		UpdateContext: resourceLicensePolicyUpdate,
+		DeleteContext: resourceLicensePolicyDelete,
+		CustomizeDiff: customdiff.All(customizeGroupIdDiff, resourceLicensePolicyCustomizeDiff),
+		Importer: &schema.ResourceImporter{
+			StateContext: importGroupResource,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: s,
+	}
+}
+
+func resourceLicensePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := resourceGroupOptions(d, m)
+
+	policy, err := api.CreatePolicy(ctx, so, getLicensePolicyState(d))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(policy.Id)
+
+	return resourceLicensePolicyRead(ctx, d, m)
+}
+
+func resourceLicensePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := resourceGroupOptions(d, m)
+
+	policy, err := api.GetPolicy(ctx, so, d.Id())
+
+	if errors.Is(err, api.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if policy.Type != api.PolicyTypeLicense {
+		return diag.Errorf("policy %s is a %s policy, not a license policy", d.Id(), policy.Type)
+	}
+
+	licenses := make([]interface{}, 0, len(policy.LicenseRules))
+	for _, rule := range policy.LicenseRules {
+		licenses = append(licenses, map[string]interface{}{
+			"spdx_id":      rule.License,
+			"severity":     rule.Severity,
+			"instructions": rule.Instructions,
+		})
+	}
+
+	d.Set("group_id", so.GroupId)
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	d.Set("license", licenses)
+	setPolicyAttachmentState(policy.Attachments, d)
+
+	return diags
+}
+
+func resourceLicensePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := resourceGroupOptions(d, m)
+
+	_, err := api.UpdatePolicy(ctx, so, d.Id(), getLicensePolicyState(d))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceLicensePolicyRead(ctx, d, m)
+}
+
+func resourceLicensePolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := resourceGroupOptions(d, m)
+
+	err := api.DeletePolicy(ctx, so, d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// Each license can only be given a single severity within a policy.
+func resourceLicensePolicyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	seen := make(map[string]bool)
+
+	for _, v := range d.Get("license").(*schema.Set).List() {
+		license := v.(map[string]interface{})["spdx_id"].(string)
+
+		if license == "" {
+			continue
+		}
+
+		if seen[license] {
+			return fmt.Errorf("license %q is declared more than once", license)
+		}
+		seen[license] = true
+	}
+
+	return nil
+}
+
+func getLicensePolicyState(d *schema.ResourceData) api.Policy {
+	var rules []api.LicensePolicyRule
+
+	for _, v := range d.Get("license").(*schema.Set).List() {
+		license := v.(map[string]interface{})
+		rules = append(rules, api.LicensePolicyRule{
+			License:      license["spdx_id"].(string),
+			Severity:     license["severity"].(string),
+			Instructions: license["instructions"].(string),
+		})
+	}
+
+	return api.Policy{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		Type:         api.PolicyTypeLicense,
+		LicenseRules: rules,
+		Attachments:  getPolicyAttachmentState(d),
+	}
+}
@@ -0,0 +1,84 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func TestAccLicensePolicy(t *testing.T) {
+	rName := acctest.RandomWithPrefix(testAccPrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy("snyk_license_policy"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLicensePolicy(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_license_policy.license_test", "name", rName),
+					resource.TestCheckResourceAttr("snyk_license_policy.license_test", "group_id", os.Getenv("SNYK_API_GROUP")),
+					resource.TestCheckResourceAttr("snyk_license_policy.license_test", "license.#", "2"),
+					resource.TestCheckResourceAttr("snyk_license_policy.license_test", "organizations.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "snyk_license_policy.license_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccLicensePolicy(name string) string {
+	return fmt.Sprintf(`
+	resource "snyk_organization" "license_test_org" {
+		name = "%[1]s"
+		deletion_protection = false
+	}
+
+	resource "snyk_license_policy" "license_test" {
+		name          = "%[1]s"
+		organizations = [snyk_organization.license_test_org.id]
+
+		license {
+			spdx_id  = "AGPL-3.0-only"
+			severity = "high"
+		}
+
+		license {
+			spdx_id      = "LGPL-2.1-only"
+			severity     = "medium"
+			instructions = "Dynamic linking only"
+		}
+	}
+	`, name)
+}
+
+func testAccCheckPolicyDestroy(resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		so := testAccOptions()
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			_, err := api.GetPolicy(context.Background(), so.InGroup(rs.Primary.Attributes["group_id"]), rs.Primary.ID)
+
+			if err == nil {
+				return fmt.Errorf("policy %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -2,93 +2,323 @@ package snyk
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
 )
 
-func resourceOrganization() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceOrganizationCreate,
-		ReadContext:   resourceOrganizationRead,
-		DeleteContext: resourceOrganizationDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-		Schema: map[string]*schema.Schema{
-			"created": {
-				Type:     schema.TypeString,
+var (
+	_ resource.ResourceWithImportState = &organizationResource{}
+	_ resource.ResourceWithModifyPlan  = &organizationResource{}
+)
+
+type organizationResource struct {
+	so api.SnykOptions
+}
+
+type organizationModel struct {
+	Id                 types.String   `tfsdk:"id"`
+	Created            types.String   `tfsdk:"created"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	ForceDestroy       types.Bool     `tfsdk:"force_destroy"`
+	GroupId            types.String   `tfsdk:"group_id"`
+	Name               types.String   `tfsdk:"name"`
+	Slug               types.String   `tfsdk:"slug"`
+	Url                types.String   `tfsdk:"url"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func newOrganizationResource() resource.Resource {
+	return &organizationResource{}
+}
+
+func (r *organizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (r *organizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	computed := schema.StringAttribute{
+		Computed:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":      computed,
+			"created": computed,
+			"deletion_protection": schema.BoolAttribute{
+				Optional: true,
 				Computed: true,
+				Default:  booldefault.StaticBool(true),
 			},
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-			"slug": {
-				Type:     schema.TypeString,
+			"force_destroy": schema.BoolAttribute{
+				Optional: true,
 				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"url": {
-				Type:     schema.TypeString,
+			// Snyk's API can't move an organization between groups, so changing it replaces the organization
+			"group_id": schema.StringAttribute{
+				Optional: true,
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"slug": computed,
+			"url":  computed,
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
-func resourceOrganizationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	so := m.(api.SnykOptions)
-	name := d.Get("name").(string)
+func (r *organizationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if so, ok := providerOptions(req.ProviderData, &resp.Diagnostics); ok {
+		r.so = so
+	}
+}
+
+func (r *organizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data organizationModel
+
+	ctx, span := startSpan(ctx, "snyk_organization", "create")
+	defer func() { endFrameworkSpan(span, data.Id.ValueString(), resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	org, err := api.CreateOrganization(so, name)
+	so := groupOptions(r.so, data.GroupId)
+	org, err := api.CreateOrganization(ctx, so, data.Name.ValueString())
 
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error creating organization", err.Error())
+		return
 	}
 
-	d.SetId(org.Id)
-	d.Set("created", org.Created.String())
-	d.Set("name", org.Name)
-	d.Set("slug", org.Slug)
-	d.Set("url", org.Url)
+	data.Id = types.StringValue(org.Id)
+	data.GroupId = types.StringValue(so.GroupId)
+	data.setOrganization(org)
+
+	// read the organization back, so that its values match those later reads will see
+	org, err = api.GetOrganization(ctx, so, org.Id)
 
-	return resourceOrganizationRead(ctx, d, m)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading organization", err.Error())
+	} else {
+		data.setOrganization(org)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func resourceOrganizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+func (r *organizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data organizationModel
+
+	ctx, span := startSpan(ctx, "snyk_organization", "read")
+	defer func() { endFrameworkSpan(span, data.Id.ValueString(), resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	so := m.(api.SnykOptions)
-	id := d.Id()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	org, err := api.GetOrganization(so, id)
+	so := groupOptions(r.so, data.GroupId)
+	org, err := api.GetOrganization(ctx, so, data.Id.ValueString())
+
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error reading organization", err.Error())
+		return
+	}
+
+	// state from before group_id existed, or imported without a group, is in the provider's group
+	data.GroupId = types.StringValue(so.GroupId)
+	data.setOrganization(org)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Changing group_id moves the organization to the new group, keeping its projects and history. Neither
+// deletion_protection nor force_destroy is sent to Snyk.
+func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data organizationModel
+
+	ctx, span := startSpan(ctx, "snyk_organization", "update")
+	defer func() { endFrameworkSpan(span, data.Id.ValueString(), resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan plans an unset group_id as the provider's group. UseStateForUnknown would otherwise keep the group
+// from state, and removing an override would leave the organization in the other group.
+func (r *organizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.so.GroupId == "" {
+		return
+	}
+
+	var groupId types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("group_id"), &groupId)...)
+
+	if resp.Diagnostics.HasError() || !groupId.IsNull() {
+		return
 	}
 
-	d.Set("created", org.Created.String())
-	d.Set("name", org.Name)
-	d.Set("slug", org.Slug)
-	d.Set("url", org.Url)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("group_id"), r.so.GroupId)...)
 
-	return diags
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// the attribute's plan modifiers ran before the group was filled in, so they couldn't ask for replacement
+	var current types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("group_id"), &current)...)
+
+	if !current.IsNull() && current.ValueString() != r.so.GroupId {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("group_id"))
+	}
 }
 
-func resourceOrganizationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data organizationModel
+
+	ctx, span := startSpan(ctx, "snyk_organization", "delete")
+	defer func() { endFrameworkSpan(span, data.Id.ValueString(), resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	so := groupOptions(r.so, data.GroupId)
+	err := checkOrganizationDeletable(ctx, so, data)
 
-	so := m.(api.SnykOptions)
-	id := d.Id()
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting organization", err.Error())
+		return
+	}
 
-	err := api.DeleteOrganization(so, id)
+	err = api.DeleteOrganization(ctx, so, data.Id.ValueString())
 
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error deleting organization", err.Error())
+	}
+}
+
+// Organizations are imported by ID, or as GROUP_ID/ORG_ID when they're outside the provider's group. Imported
+// organizations take the default safeguards, rather than planning an update to set them.
+func (r *organizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+
+	if groupId, orgId, ok := strings.Cut(req.ID, "/"); ok {
+		id = orgId
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupId)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
+
+func (data *organizationModel) setOrganization(org *api.Organization) {
+	data.Created = types.StringValue(org.Created.String())
+	data.Name = types.StringValue(org.Name)
+	data.Slug = types.StringValue(org.Slug)
+	data.Url = types.StringValue(org.Url)
+}
+
+// Deleting an organization also deletes all of its projects and their history, so it's guarded three
+// ways: the provider can forbid it, each organization is protected by default, and organizations that
+// still have projects are only deleted with force_destroy.
+func checkOrganizationDeletable(ctx context.Context, so api.SnykOptions, data organizationModel) error {
+	name := data.Name.ValueString()
+
+	if so.PreventOrganizationDeletion {
+		return fmt.Errorf("organization %q can't be deleted: the provider is configured with prevent_organization_deletion", name)
+	}
+
+	if data.DeletionProtection.ValueBool() {
+		return fmt.Errorf("organization %q can't be deleted while deletion_protection is enabled; set deletion_protection = false and apply first", name)
+	}
+
+	if !data.ForceDestroy.ValueBool() {
+		projects, err := api.ListProjects(ctx, so, data.Id.ValueString())
+
+		if err != nil {
+			return err
+		}
+
+		if len(projects) > 0 {
+			return fmt.Errorf("organization %q still contains %d projects; set force_destroy = true and apply to delete it along with them", name, len(projects))
+		}
 	}
 
-	d.SetId("")
-	return diags
+	return nil
 }
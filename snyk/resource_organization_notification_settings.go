@@ -0,0 +1,134 @@
+package snyk
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func resourceOrganizationNotificationSettings() *schema.Resource {
+	defaults := api.DefaultNotificationSettings
+
+	return &schema.Resource{
+		CreateContext: resourceOrganizationNotificationSettingsUpdate,
+		ReadContext:   resourceOrganizationNotificationSettingsRead,
+		UpdateContext: resourceOrganizationNotificationSettingsUpdate,
+		DeleteContext: resourceOrganizationNotificationSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"new_issues_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  defaults.NewIssues.Enabled,
+			},
+			"new_issues_severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaults.NewIssues.IssueSeverity,
+				ValidateFunc: validation.StringInSlice([]string{"all", "low", "medium", "high", "critical"}, false),
+			},
+			"new_issues_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaults.NewIssues.IssueType,
+				ValidateFunc: validation.StringInSlice([]string{"all", "vuln", "license", "none"}, false),
+			},
+			"project_imported_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  defaults.ProjectImported.Enabled,
+			},
+			"test_limit_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  defaults.TestLimit.Enabled,
+			},
+			"weekly_report_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  defaults.WeeklyReport.Enabled,
+			},
+		},
+	}
+}
+
+func resourceOrganizationNotificationSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := m.(api.SnykOptions)
+	orgId := d.Get("organization").(string)
+
+	settings := api.NotificationSettings{
+		NewIssues: api.NewIssuesNotification{
+			Enabled:       d.Get("new_issues_enabled").(bool),
+			IssueSeverity: d.Get("new_issues_severity").(string),
+			IssueType:     d.Get("new_issues_type").(string),
+		},
+		ProjectImported: api.NotificationSetting{Enabled: d.Get("project_imported_enabled").(bool)},
+		TestLimit:       api.NotificationSetting{Enabled: d.Get("test_limit_enabled").(bool)},
+		WeeklyReport:    api.NotificationSetting{Enabled: d.Get("weekly_report_enabled").(bool)},
+	}
+
+	_, err := api.UpdateNotificationSettings(ctx, so, orgId, settings)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(orgId)
+
+	return resourceOrganizationNotificationSettingsRead(ctx, d, m)
+}
+
+func resourceOrganizationNotificationSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+
+	settings, err := api.GetNotificationSettings(ctx, so, d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("organization", d.Id())
+	d.Set("new_issues_enabled", settings.NewIssues.Enabled)
+	d.Set("new_issues_severity", settings.NewIssues.IssueSeverity)
+	d.Set("new_issues_type", settings.NewIssues.IssueType)
+	d.Set("project_imported_enabled", settings.ProjectImported.Enabled)
+	d.Set("test_limit_enabled", settings.TestLimit.Enabled)
+	d.Set("weekly_report_enabled", settings.WeeklyReport.Enabled)
+
+	return diags
+}
+
+// Destroying the resource puts the organization back onto Snyk's default notification settings.
+func resourceOrganizationNotificationSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+
+	_, err := api.UpdateNotificationSettings(ctx, so, d.Id(), api.DefaultNotificationSettings)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
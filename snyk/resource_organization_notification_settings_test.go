@@ -0,0 +1,78 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func TestAccOrganizationNotificationSettings(t *testing.T) {
+	rOrgName := acctest.RandomWithPrefix(testAccPrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationNotificationSettings(rOrgName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotificationSettingsValues("snyk_organization_notification_settings.notif_test"),
+					resource.TestCheckResourceAttr("snyk_organization_notification_settings.notif_test", "new_issues_severity", "high"),
+					resource.TestCheckResourceAttr("snyk_organization_notification_settings.notif_test", "weekly_report_enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "snyk_organization_notification_settings.notif_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccOrganizationNotificationSettings(name string) string {
+	return fmt.Sprintf(`
+	resource "snyk_organization" "notif_test_org" {
+		name = "%s"
+		deletion_protection = false
+	}
+
+	resource "snyk_organization_notification_settings" "notif_test" {
+		organization          = snyk_organization.notif_test_org.id
+		new_issues_severity   = "high"
+		new_issues_type       = "vuln"
+		weekly_report_enabled = false
+	}
+	`, name)
+}
+
+func testAccCheckNotificationSettingsValues(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		so := testAccOptions()
+
+		settings, err := api.GetNotificationSettings(context.Background(), so, rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if settings.NewIssues.IssueSeverity != "high" {
+			return fmt.Errorf("bad new issues severity, expected \"high\", got: %#v", settings.NewIssues.IssueSeverity)
+		}
+		if settings.WeeklyReport.Enabled {
+			return fmt.Errorf("expected weekly report to be disabled")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,146 @@
+package snyk
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+// Blocks missing from configuration are neither sent to Snyk nor read back, so settings managed elsewhere (or left
+// at their defaults) are untouched. Only request access is documented by the settings API.
+func resourceOrganizationSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOrganizationSettingsUpdate,
+		ReadContext:   resourceOrganizationSettingsRead,
+		UpdateContext: resourceOrganizationSettingsUpdate,
+		DeleteContext: resourceOrganizationSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOrganizationSettingsImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"request_access": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceOrganizationSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := m.(api.SnykOptions)
+	orgId := d.Get("organization").(string)
+
+	_, err := api.UpdateOrganizationSettings(ctx, so, orgId, getOrganizationSettingsState(d))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(orgId)
+
+	return resourceOrganizationSettingsRead(ctx, d, m)
+}
+
+func resourceOrganizationSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+
+	settings, err := api.GetOrganizationSettings(ctx, so, d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("organization", d.Id())
+	setOrganizationSettingsState(settings, d, false)
+
+	return diags
+}
+
+// Settings are left as they are on destroy, the resource only stops managing them.
+func resourceOrganizationSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
+
+// Importing takes ownership of every settings block, as there is no configuration to go by yet.
+func resourceOrganizationSettingsImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	so := m.(api.SnykOptions)
+
+	settings, err := api.GetOrganizationSettings(ctx, so, d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("organization", d.Id())
+	setOrganizationSettingsState(settings, d, true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func getOrganizationSettingsState(d *schema.ResourceData) api.OrganizationSettings {
+	var settings api.OrganizationSettings
+
+	if block, ok := getSettingsBlock(d, "request_access"); ok {
+		settings.RequestAccess = &api.RequestAccessSettings{Enabled: block["enabled"].(bool)}
+	}
+
+	return settings
+}
+
+// setOrganizationSettingsState only refreshes blocks already tracked in state, unless all is set.
+func setOrganizationSettingsState(settings *api.OrganizationSettings, d *schema.ResourceData, all bool) {
+	owned := func(key string) bool {
+		_, ok := getSettingsBlock(d, key)
+		return all || ok
+	}
+
+	if !owned("request_access") {
+		return
+	}
+
+	// a section Snyk no longer returns is cleared, so the plan shows it being set again
+	if settings.RequestAccess == nil {
+		d.Set("request_access", nil)
+		return
+	}
+
+	d.Set("request_access", []interface{}{map[string]interface{}{
+		"enabled": settings.RequestAccess.Enabled,
+	}})
+}
+
+func getSettingsBlock(d *schema.ResourceData, key string) (map[string]interface{}, bool) {
+	if list, ok := d.Get(key).([]interface{}); ok && len(list) > 0 && list[0] != nil {
+		return list[0].(map[string]interface{}), true
+	}
+
+	return nil, false
+}
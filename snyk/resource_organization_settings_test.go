@@ -0,0 +1,122 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func TestAccOrganizationSettings(t *testing.T) {
+	rOrgName := acctest.RandomWithPrefix(testAccPrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationSettings(rOrgName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOrganizationSettingsValues("snyk_organization_settings.settings_test"),
+					resource.TestCheckResourceAttr("snyk_organization_settings.settings_test", "request_access.0.enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestOrganizationSettingsRead(t *testing.T) {
+	cases := map[string]struct {
+		response string
+		config   map[string]interface{}
+		expected []interface{}
+	}{
+		"refreshed": {
+			response: `{"requestAccess": {"enabled": false}}`,
+			config:   map[string]interface{}{"request_access": []interface{}{map[string]interface{}{"enabled": true}}},
+			expected: []interface{}{map[string]interface{}{"enabled": false}},
+		},
+		"missing from the response": {
+			response: `{}`,
+			config:   map[string]interface{}{"request_access": []interface{}{map[string]interface{}{"enabled": true}}},
+			expected: []interface{}{},
+		},
+		"not owned": {
+			response: `{"requestAccess": {"enabled": true}}`,
+			config:   map[string]interface{}{},
+			expected: []interface{}{},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, c.response)
+			}))
+			defer server.Close()
+
+			so := api.SnykOptions{ApiKey: "key", Endpoint: server.URL}
+
+			c.config["organization"] = "org-id"
+			d := schema.TestResourceDataRaw(t, resourceOrganizationSettings().Schema, c.config)
+			d.SetId("org-id")
+
+			if diags := resourceOrganizationSettingsRead(context.Background(), d, so); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			if got := d.Get("request_access").([]interface{}); !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("expected request_access %#v, got %#v", c.expected, got)
+			}
+		})
+	}
+}
+
+func testAccOrganizationSettings(name string) string {
+	return fmt.Sprintf(`
+	resource "snyk_organization" "settings_test_org" {
+		name = "%s"
+		deletion_protection = false
+	}
+
+	resource "snyk_organization_settings" "settings_test" {
+		organization = snyk_organization.settings_test_org.id
+
+		request_access {
+			enabled = true
+		}
+	}
+	`, name)
+}
+
+func testAccCheckOrganizationSettingsValues(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		so := testAccOptions()
+
+		settings, err := api.GetOrganizationSettings(context.Background(), so, rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if settings.RequestAccess == nil || !settings.RequestAccess.Enabled {
+			return fmt.Errorf("expected request access to be enabled")
+		}
+
+		return nil
+	}
+}
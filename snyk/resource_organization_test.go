@@ -1,12 +1,19 @@
 package snyk
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
 	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
 )
 
@@ -17,12 +24,11 @@ func TestAccOrganization(t *testing.T) {
 	// collisions from multiple concurrent tests.
 	// the acctest package includes many helpers such as RandStringFromCharSet
 	// See https://pkg.go.dev/github.com/hashicorp/terraform-plugin-sdk/helper/acctest
-	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	rName := acctest.RandomWithPrefix(testAccPrefix)
 
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckOrgDestroy(rName),
+	testAccFakeSnyk(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckOrgDestroy(rName),
 		Steps: []resource.TestStep{
 			{
 				// use a dynamic configuration with the random name from above
@@ -41,19 +47,159 @@ func TestAccOrganization(t *testing.T) {
 	})
 }
 
+// Organizations can be managed in a group other than the provider's. Only the fake Snyk API has a second
+// group the test credentials can use.
+func TestAccOrganizationGroupOverride(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	if server == nil {
+		t.Skip("requires a second group, which only the fake Snyk API provides")
+	}
+
+	rName := acctest.RandomWithPrefix(testAccPrefix)
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckOrgDestroy(rName),
+			testAccCheckOrgDestroyInGroup(fakesnyk.OtherGroupId, rName+"-other"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrgGroupOverride(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_organization.provider_group", "group_id", fakesnyk.GroupId),
+					resource.TestCheckResourceAttr("snyk_organization.other_group", "group_id", fakesnyk.OtherGroupId),
+					resource.TestCheckResourceAttr("data.snyk_organization.other_group", "name", rName+"-other"),
+					resource.TestCheckResourceAttr("data.snyk_organization.other_group", "group_id", fakesnyk.OtherGroupId),
+					func(s *terraform.State) error {
+						id := s.RootModule().Resources["snyk_organization.other_group"].Primary.ID
+
+						if org := server.Org(id); org == nil || org.GroupId != fakesnyk.OtherGroupId {
+							return fmt.Errorf("expected organization %s in group %s, got: %#v", id, fakesnyk.OtherGroupId, org)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName: "snyk_organization.other_group",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fakesnyk.OtherGroupId + "/" + s.RootModule().Resources["snyk_organization.other_group"].Primary.ID, nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+		},
+	})
+}
+
+// Changing group_id replaces the organization in the new group, and removing it replaces the organization in
+// the provider's group.
+func TestAccOrganizationGroupChange(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	if server == nil {
+		t.Skip("requires a second group, which only the fake Snyk API provides")
+	}
+
+	var org = new(api.Organization)
+	rName := acctest.RandomWithPrefix(testAccPrefix)
+
+	testAccReplacedIn := func(groupId string) resource.TestCheckFunc {
+		return resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr("snyk_organization.org_test_org", "group_id", groupId),
+			func(s *terraform.State) error {
+				id := s.RootModule().Resources["snyk_organization.org_test_org"].Primary.ID
+
+				if id == org.Id || server.Org(org.Id) != nil {
+					return fmt.Errorf("expected organization %s to be replaced, got %s", org.Id, id)
+				}
+
+				if replacement := server.Org(id); replacement == nil || replacement.GroupId != groupId {
+					return fmt.Errorf("expected organization %s in group %s, got: %#v", id, groupId, replacement)
+				}
+
+				org.Id = id
+
+				return nil
+			},
+		)
+	}
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckOrgDestroy(rName),
+			testAccCheckOrgDestroyInGroup(fakesnyk.OtherGroupId, rName),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrgInGroup(rName, fakesnyk.GroupId),
+				Check:  testAccCheckOrgExists("snyk_organization.org_test_org", org),
+			},
+			{
+				Config: testAccOrgInGroup(rName, fakesnyk.OtherGroupId),
+				Check:  testAccReplacedIn(fakesnyk.OtherGroupId),
+			},
+			{
+				Config: testAccOrg(rName),
+				Check:  testAccReplacedIn(fakesnyk.GroupId),
+			},
+		},
+	})
+}
+
+func testAccOrgInGroup(name string, groupId string) string {
+	return fmt.Sprintf(`
+	resource "snyk_organization" "org_test_org" {
+		name = "%s"
+		group_id = "%s"
+		deletion_protection = false
+	}`, name, groupId)
+}
+
+func testAccOrgGroupOverride(name string) string {
+	return fmt.Sprintf(`
+	resource "snyk_organization" "provider_group" {
+		name = "%[1]s"
+		deletion_protection = false
+	}
+
+	resource "snyk_organization" "other_group" {
+		name = "%[1]s-other"
+		group_id = "%[2]s"
+		deletion_protection = false
+	}
+
+	data "snyk_organization" "other_group" {
+		id = snyk_organization.other_group.id
+		group_id = snyk_organization.other_group.group_id
+	}`, name, fakesnyk.OtherGroupId)
+}
+
 func testAccOrg(name string) string {
 	return fmt.Sprintf(`
 	resource "snyk_organization" "org_test_org" {
 		name = "%s"
+		deletion_protection = false
 	}`, name)
 }
 
 func testAccCheckOrgDestroy(name string) resource.TestCheckFunc {
+	return testAccCheckOrgDestroyInGroup("", name)
+}
+
+// testAccCheckOrgDestroyInGroup checks an organization is gone from the given group, or the provider's group
+// when it's empty.
+func testAccCheckOrgDestroyInGroup(groupId string, name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// retrieve the client options from the test setup
-		so := testAccProviders["snyk"].Meta().(api.SnykOptions)
+		so := testAccOptions().InGroup(groupId)
 
-		exists, err := api.OrganizationExistsByName(so, name)
+		exists, err := api.OrganizationExistsByName(context.Background(), so, name)
 
 		if err != nil {
 			return err
@@ -86,10 +232,10 @@ func testAccCheckOrgExists(n string, org *api.Organization) resource.TestCheckFu
 		}
 
 		// retrieve the client options from the test setup
-		so := testAccProviders["snyk"].Meta().(api.SnykOptions)
+		so := testAccOptions()
 		orgId := rs.Primary.ID
 
-		res, err := api.GetOrganization(so, orgId)
+		res, err := api.GetOrganization(context.Background(), so, orgId)
 
 		if err != nil {
 			return err
@@ -101,3 +247,88 @@ func testAccCheckOrgExists(n string, org *api.Organization) resource.TestCheckFu
 		return nil
 	}
 }
+
+func TestOrganizationDeleteSafeguards(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	so := api.SnykOptions{GroupId: fakesnyk.GroupId, ApiKey: fakesnyk.ApiKey, Endpoint: server.URL}
+
+	org, err := api.CreateOrganization(ctx, so, testAccPrefix+"-protected")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.AddProject(org.Id, "owner/repo:package.json", "npm", "github")
+
+	// delete through the resource, as Terraform would, returning the error it reports
+	del := func(so api.SnykOptions, deletionProtection bool, forceDestroy bool) error {
+		r := &organizationResource{so: so}
+
+		var schemaResp fwresource.SchemaResponse
+		r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+
+		for name, value := range map[string]interface{}{
+			"id":                  org.Id,
+			"name":                org.Name,
+			"group_id":            so.GroupId,
+			"deletion_protection": deletionProtection,
+			"force_destroy":       forceDestroy,
+		} {
+			if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+				t.Fatal(diags)
+			}
+		}
+
+		resp := fwresource.DeleteResponse{State: state}
+		r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+
+		for _, d := range resp.Diagnostics.Errors() {
+			return fmt.Errorf("%s: %s", d.Summary(), d.Detail())
+		}
+
+		return nil
+	}
+
+	cases := []struct {
+		so                 api.SnykOptions
+		deletionProtection bool
+		forceDestroy       bool
+		expected           string
+	}{
+		{so, true, false, "deletion_protection is enabled"},
+		{so, false, false, "still contains 1 projects"},
+		{
+			api.SnykOptions{GroupId: so.GroupId, ApiKey: so.ApiKey, Endpoint: so.Endpoint, PreventOrganizationDeletion: true},
+			false, true,
+			"prevent_organization_deletion",
+		},
+	}
+
+	for _, c := range cases {
+		err := del(c.so, c.deletionProtection, c.forceDestroy)
+
+		if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("expected an error containing %q, got: %v", c.expected, err)
+		}
+
+		if server.Org(org.Id) == nil {
+			t.Fatal("expected the organization not to be deleted")
+		}
+	}
+
+	if err := del(so, false, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if server.Org(org.Id) != nil {
+		t.Error("expected the organization to be deleted with force_destroy")
+	}
+}
@@ -0,0 +1,337 @@
+package snyk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+// securityPolicyConditionValues lists the values each categorical condition field accepts.
+var securityPolicyConditionValues = map[string][]string{
+	"severity":         {"critical", "high", "medium", "low"},
+	"exploit_maturity": {"mature", "proof-of-concept", "no-known-exploit", "no-data"},
+	"social_trends":    {"trending"},
+}
+
+var securityPolicyCategoricalOperators = []string{"includes", "not_includes"}
+var securityPolicyNumericOperators = []string{"gte", "lte"}
+
+func resourceSecurityPolicy() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"group_id": getGroupIdSchema(true),
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"rule": {
+			Type:     schema.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"condition": {
+						Type:     schema.TypeList,
+						Required: true,
+						MinItems: 1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"field": {
+									Type:         schema.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringInSlice([]string{"severity", "exploit_maturity", "social_trends", "cvss_score"}, false),
+								},
+								"operator": {
+									Type:         schema.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringInSlice(append(securityPolicyCategoricalOperators, securityPolicyNumericOperators...), false),
+								},
+								"value": {
+									Type:     schema.TypeString,
+									Required: true,
+								},
+							},
+						},
+					},
+					"action": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"change_severity", "ignore"}, false),
+					},
+					"severity": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"critical", "high", "medium", "low"}, false),
+					},
+					"ignore_type": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"wont-fix", "not-vulnerable", "temporary-ignore"}, false),
+					},
+					"reason": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	for k, v := range getPolicyAttachmentSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceSecurityPolicyCreate,
+		ReadContext:   resourceSecurityPolicyRead,
+		UpdateContext: resourceSecurityPolicyUpdate,
+		DeleteContext: resourceSecurityPolicyDelete,
+		CustomizeDiff: customdiff.All(customizeGroupIdDiff, resourceSecurityPolicyCustomizeDiff),
+		Importer: &schema.ResourceImporter{
+			StateContext: importGroupResource,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: s,
+	}
+}
+
+func resourceSecurityPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := resourceGroupOptions(d, m)
+
+	policy, err := api.CreatePolicy(ctx, so, getSecurityPolicyState(d))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(policy.Id)
+
+	return resourceSecurityPolicyRead(ctx, d, m)
+}
+
+func resourceSecurityPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := resourceGroupOptions(d, m)
+
+	policy, err := api.GetPolicy(ctx, so, d.Id())
+
+	if errors.Is(err, api.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if policy.Type != api.PolicyTypeSecurity {
+		return diag.Errorf("policy %s is a %s policy, not a security policy", d.Id(), policy.Type)
+	}
+
+	rules := make([]interface{}, 0, len(policy.SecurityRules))
+	for _, rule := range policy.SecurityRules {
+		conditions := make([]interface{}, 0, len(rule.Conditions))
+		for _, condition := range rule.Conditions {
+			conditions = append(conditions, map[string]interface{}{
+				"field":    condition.Field,
+				"operator": condition.Operator,
+				"value":    condition.Value,
+			})
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"name":        rule.Name,
+			"condition":   conditions,
+			"action":      rule.Action.Type,
+			"severity":    rule.Action.Severity,
+			"ignore_type": rule.Action.IgnoreType,
+			"reason":      rule.Action.Reason,
+		})
+	}
+
+	d.Set("group_id", so.GroupId)
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	d.Set("rule", rules)
+	setPolicyAttachmentState(policy.Attachments, d)
+
+	return diags
+}
+
+func resourceSecurityPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := resourceGroupOptions(d, m)
+
+	_, err := api.UpdatePolicy(ctx, so, d.Id(), getSecurityPolicyState(d))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSecurityPolicyRead(ctx, d, m)
+}
+
+func resourceSecurityPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := resourceGroupOptions(d, m)
+
+	err := api.DeletePolicy(ctx, so, d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// Conditions and actions are validated as a whole at plan time, as which operators, values and action
+// arguments are valid depends on the other attributes in the block.
+func resourceSecurityPolicyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for i, v := range d.Get("rule").([]interface{}) {
+		rule := v.(map[string]interface{})
+
+		for _, c := range rule["condition"].([]interface{}) {
+			condition := c.(map[string]interface{})
+
+			err := validateSecurityPolicyCondition(condition["field"].(string), condition["operator"].(string), condition["value"].(string))
+
+			if err != nil {
+				return fmt.Errorf("rule.%d: %w", i, err)
+			}
+		}
+
+		// values derived from other resources show up as empty until they are known
+		if !d.NewValueKnown(fmt.Sprintf("rule.%d.severity", i)) || !d.NewValueKnown(fmt.Sprintf("rule.%d.ignore_type", i)) {
+			continue
+		}
+
+		err := validateSecurityPolicyAction(rule["action"].(string), rule["severity"].(string), rule["ignore_type"].(string))
+
+		if err != nil {
+			return fmt.Errorf("rule.%d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateSecurityPolicyCondition skips empty attributes, as they may not be known until apply.
+func validateSecurityPolicyCondition(field string, operator string, value string) error {
+	if field == "" || operator == "" || value == "" {
+		return nil
+	}
+
+	if field == "cvss_score" {
+		if !stringInSlice(operator, securityPolicyNumericOperators) {
+			return fmt.Errorf("operator %q can't be used with %s, expected one of %v", operator, field, securityPolicyNumericOperators)
+		}
+
+		score, err := strconv.ParseFloat(value, 64)
+
+		if err != nil || score < 0 || score > 10 {
+			return fmt.Errorf("value %q for %s must be a number between 0 and 10", value, field)
+		}
+
+		return nil
+	}
+
+	if !stringInSlice(operator, securityPolicyCategoricalOperators) {
+		return fmt.Errorf("operator %q can't be used with %s, expected one of %v", operator, field, securityPolicyCategoricalOperators)
+	}
+
+	if !stringInSlice(value, securityPolicyConditionValues[field]) {
+		return fmt.Errorf("value %q is not valid for %s, expected one of %v", value, field, securityPolicyConditionValues[field])
+	}
+
+	return nil
+}
+
+func validateSecurityPolicyAction(action string, severity string, ignoreType string) error {
+	switch action {
+	case "change_severity":
+		if severity == "" {
+			return errors.New("severity is required when action is \"change_severity\"")
+		}
+		if ignoreType != "" {
+			return errors.New("ignore_type can only be set when action is \"ignore\"")
+		}
+	case "ignore":
+		if ignoreType == "" {
+			return errors.New("ignore_type is required when action is \"ignore\"")
+		}
+		if severity != "" {
+			return errors.New("severity can only be set when action is \"change_severity\"")
+		}
+	}
+
+	return nil
+}
+
+func getSecurityPolicyState(d *schema.ResourceData) api.Policy {
+	var rules []api.SecurityPolicyRule
+
+	for _, v := range d.Get("rule").([]interface{}) {
+		rule := v.(map[string]interface{})
+
+		var conditions []api.SecurityPolicyCondition
+		for _, c := range rule["condition"].([]interface{}) {
+			condition := c.(map[string]interface{})
+			conditions = append(conditions, api.SecurityPolicyCondition{
+				Field:    condition["field"].(string),
+				Operator: condition["operator"].(string),
+				Value:    condition["value"].(string),
+			})
+		}
+
+		rules = append(rules, api.SecurityPolicyRule{
+			Name:       rule["name"].(string),
+			Conditions: conditions,
+			Action: api.SecurityPolicyAction{
+				Type:       rule["action"].(string),
+				Severity:   rule["severity"].(string),
+				IgnoreType: rule["ignore_type"].(string),
+				Reason:     rule["reason"].(string),
+			},
+		})
+	}
+
+	return api.Policy{
+		Name:          d.Get("name").(string),
+		Description:   d.Get("description").(string),
+		Type:          api.PolicyTypeSecurity,
+		SecurityRules: rules,
+		Attachments:   getPolicyAttachmentState(d),
+	}
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+package snyk
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSecurityPolicy(t *testing.T) {
+	rName := acctest.RandomWithPrefix(testAccPrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy("snyk_security_policy"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecurityPolicy(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_security_policy.security_test", "name", rName),
+					resource.TestCheckResourceAttr("snyk_security_policy.security_test", "group_id", os.Getenv("SNYK_API_GROUP")),
+					resource.TestCheckResourceAttr("snyk_security_policy.security_test", "rule.#", "2"),
+					resource.TestCheckResourceAttr("snyk_security_policy.security_test", "rule.1.ignore_type", "wont-fix"),
+				),
+			},
+			{
+				ResourceName:      "snyk_security_policy.security_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestValidateSecurityPolicyCondition(t *testing.T) {
+	cases := []struct {
+		field, operator, value string
+		valid                  bool
+	}{
+		{"exploit_maturity", "includes", "mature", true},
+		{"exploit_maturity", "includes", "trending", false},
+		{"severity", "gte", "high", false},
+		{"cvss_score", "gte", "7.5", true},
+		{"cvss_score", "includes", "7.5", false},
+		{"cvss_score", "lte", "11", false},
+	}
+
+	for _, c := range cases {
+		err := validateSecurityPolicyCondition(c.field, c.operator, c.value)
+
+		if c.valid && err != nil {
+			t.Errorf("expected %s %s %s to be valid, got: %s", c.field, c.operator, c.value, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("expected %s %s %s to be invalid", c.field, c.operator, c.value)
+		}
+	}
+}
+
+func testAccSecurityPolicy(name string) string {
+	return fmt.Sprintf(`
+	resource "snyk_security_policy" "security_test" {
+		name = "%s"
+
+		project_attributes {
+			environment = ["external"]
+		}
+
+		rule {
+			name     = "Raise mature exploits"
+			action   = "change_severity"
+			severity = "critical"
+
+			condition {
+				field    = "exploit_maturity"
+				operator = "includes"
+				value    = "mature"
+			}
+		}
+
+		rule {
+			name        = "Ignore low scores"
+			action      = "ignore"
+			ignore_type = "wont-fix"
+			reason      = "Below risk threshold"
+
+			condition {
+				field    = "cvss_score"
+				operator = "lte"
+				value    = "3"
+			}
+		}
+	}
+	`, name)
+}
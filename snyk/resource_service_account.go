@@ -0,0 +1,210 @@
+package snyk
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func resourceServiceAccount() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServiceAccountCreate,
+		ReadContext:   resourceServiceAccountRead,
+		UpdateContext: resourceServiceAccountUpdate,
+		DeleteContext: resourceServiceAccountDelete,
+		CustomizeDiff: customdiff.All(
+			// organization service accounts aren't in a group, so the provider's group doesn't apply to them
+			customdiff.If(isGroupServiceAccount, customizeGroupIdDiff),
+			resourceServiceAccountCustomizeDiff,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": getServiceAccountGroupIdSchema(),
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"role_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"auth_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "api_key",
+				ValidateFunc: validation.StringInSlice([]string{"api_key", "oauth_client_secret"}, false),
+			},
+			"rotation_trigger": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceServiceAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := m.(api.SnykOptions)
+	scope := getServiceAccountScope(d, m)
+
+	name := d.Get("name").(string)
+	roleId := d.Get("role_id").(string)
+	authType := d.Get("auth_type").(string)
+
+	sa, err := api.CreateServiceAccount(ctx, so, scope, name, roleId, authType)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(sa.Id)
+	setServiceAccountToken(sa, d)
+
+	return resourceServiceAccountRead(ctx, d, m)
+}
+
+func resourceServiceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+	scope := getServiceAccountScope(d, m)
+
+	sa, err := api.GetServiceAccount(ctx, so, scope, d.Id())
+
+	if errors.Is(err, api.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("group_id", scope.GroupId)
+	d.Set("name", sa.Name)
+	d.Set("role_id", sa.RoleId)
+	d.Set("auth_type", sa.AuthType)
+	d.Set("client_id", sa.ClientId)
+
+	return diags
+}
+
+func resourceServiceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := m.(api.SnykOptions)
+	scope := getServiceAccountScope(d, m)
+
+	if d.HasChange("name") {
+		_, err := api.UpdateServiceAccountName(ctx, so, scope, d.Id(), d.Get("name").(string))
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// api_key accounts are replaced by CustomizeDiff, so only OAuth secrets are rotated in place
+	if d.HasChange("rotation_trigger") {
+		sa, err := api.RotateServiceAccountSecret(ctx, so, scope, d.Id())
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		setServiceAccountToken(sa, d)
+	}
+
+	return resourceServiceAccountRead(ctx, d, m)
+}
+
+func resourceServiceAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+	scope := getServiceAccountScope(d, m)
+
+	err := api.DeleteServiceAccount(ctx, so, scope, d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// API keys can only be obtained when a service account is created, so rotating one means replacing the account.
+// OAuth secrets are rotated in place, leaving the new credentials unknown until the rotation is applied.
+func resourceServiceAccountCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("rotation_trigger") || d.Id() == "" {
+		return nil
+	}
+
+	if d.Get("auth_type").(string) == "api_key" {
+		return d.ForceNew("rotation_trigger")
+	}
+
+	if err := d.SetNewComputed("token"); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("client_id")
+}
+
+func getServiceAccountGroupIdSchema() *schema.Schema {
+	s := getGroupIdSchema(true)
+	s.ConflictsWith = []string{"organization"}
+
+	return s
+}
+
+func isGroupServiceAccount(ctx context.Context, d *schema.ResourceDiff, m interface{}) bool {
+	return d.NewValueKnown("organization") && d.Get("organization").(string) == ""
+}
+
+func getServiceAccountScope(d *schema.ResourceData, m interface{}) api.ServiceAccountScope {
+	if orgId := d.Get("organization").(string); orgId != "" {
+		return api.ServiceAccountScope{OrgId: orgId}
+	}
+
+	return api.ServiceAccountScope{GroupId: resourceGroupOptions(d, m).GroupId}
+}
+
+func setServiceAccountToken(sa *api.ServiceAccount, d *schema.ResourceData) {
+	if sa.ApiKey != "" {
+		d.Set("token", sa.ApiKey)
+	}
+	if sa.ClientSecret != "" {
+		d.Set("token", sa.ClientSecret)
+	}
+	if sa.ClientId != "" {
+		d.Set("client_id", sa.ClientId)
+	}
+}
@@ -0,0 +1,323 @@
+package snyk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func TestAccServiceAccount(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	var sa = new(api.ServiceAccount)
+
+	rName := acctest.RandomWithPrefix(testAccPrefix)
+	roleId := testAccServiceAccountRole(t, server)
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServiceAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAccount(rName, roleId, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceAccountExists("snyk_service_account.sa_test", sa),
+					resource.TestCheckResourceAttr("snyk_service_account.sa_test", "name", rName),
+					resource.TestCheckResourceAttr("snyk_service_account.sa_test", "group_id", os.Getenv("SNYK_API_GROUP")),
+					resource.TestCheckResourceAttrSet("snyk_service_account.sa_test", "token"),
+				),
+			},
+			{
+				// rotating an api_key account replaces it with a new one
+				Config: testAccServiceAccount(rName, roleId, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceAccountExists("snyk_service_account.sa_test", sa),
+					resource.TestCheckResourceAttrSet("snyk_service_account.sa_test", "token"),
+				),
+			},
+		},
+	})
+}
+
+// Organization service accounts aren't in a group, and their OAuth secrets are rotated in place.
+func TestAccServiceAccountOrganization(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	var sa = new(api.ServiceAccount)
+
+	rName := acctest.RandomWithPrefix(testAccPrefix)
+	roleId := testAccServiceAccountRole(t, server)
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServiceAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAccountInOrg(rName, roleId, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceAccountExists("snyk_service_account.sa_test", sa),
+					resource.TestCheckResourceAttr("snyk_service_account.sa_test", "group_id", ""),
+					resource.TestCheckResourceAttrSet("snyk_service_account.sa_test", "client_id"),
+					resource.TestCheckResourceAttrSet("snyk_service_account.sa_test", "token"),
+				),
+			},
+			{
+				Config: testAccServiceAccountInOrg(rName, roleId, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceAccountExists("snyk_service_account.sa_test", sa),
+					resource.TestCheckResourceAttrPtr("snyk_service_account.sa_test", "id", &sa.Id),
+					resource.TestCheckResourceAttrSet("snyk_service_account.sa_test", "token"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceAccountInOrg(name string, roleId string, rotation string) string {
+	return fmt.Sprintf(`
+	resource "snyk_organization" "sa_test" {
+		name                = "%[1]s"
+		deletion_protection = false
+	}
+
+	resource "snyk_service_account" "sa_test" {
+		organization = snyk_organization.sa_test.id
+		name         = "%[1]s"
+		role_id      = "%[2]s"
+		auth_type    = "oauth_client_secret"
+		rotation_trigger = {
+			version = "%[3]s"
+		}
+	}`, name, roleId, rotation)
+}
+
+// Group service accounts can be created in a group other than the provider's. Only the fake Snyk API has a
+// second group the test credentials can use.
+func TestAccServiceAccountGroupOverride(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	if server == nil {
+		t.Skip("requires a second group, which only the fake Snyk API provides")
+	}
+
+	var sa = new(api.ServiceAccount)
+
+	rName := acctest.RandomWithPrefix(testAccPrefix)
+	roleId := testAccServiceAccountRole(t, server)
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServiceAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAccountInGroup(rName, roleId, fakesnyk.OtherGroupId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceAccountExists("snyk_service_account.sa_test", sa),
+					resource.TestCheckResourceAttr("snyk_service_account.sa_test", "group_id", fakesnyk.OtherGroupId),
+					func(s *terraform.State) error {
+						if server.ServiceAccount(fakesnyk.OtherGroupId, sa.Id) == nil {
+							return fmt.Errorf("expected service account %s in group %s", sa.Id, fakesnyk.OtherGroupId)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				// renaming is done in place, in the same group
+				Config: testAccServiceAccountInGroup(rName+"-renamed", roleId, fakesnyk.OtherGroupId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_service_account.sa_test", "name", rName+"-renamed"),
+					func(s *terraform.State) error {
+						if account := server.ServiceAccount(fakesnyk.OtherGroupId, sa.Id); account == nil || account.Name != rName+"-renamed" {
+							return fmt.Errorf("expected service account %s to be renamed in group %s, got: %#v", sa.Id, fakesnyk.OtherGroupId, account)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				// removing the override replaces the account in the provider's group
+				Config: testAccServiceAccount(rName, roleId, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_service_account.sa_test", "group_id", fakesnyk.GroupId),
+					func(s *terraform.State) error {
+						id := s.RootModule().Resources["snyk_service_account.sa_test"].Primary.ID
+
+						if server.ServiceAccount(fakesnyk.OtherGroupId, sa.Id) != nil || server.ServiceAccount(fakesnyk.GroupId, id) == nil {
+							return fmt.Errorf("expected service account %s to be replaced by %s in group %s", sa.Id, id, fakesnyk.GroupId)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// testAccServiceAccountRole returns a role for service accounts, which the fake Snyk API doesn't check.
+func testAccServiceAccountRole(t *testing.T, server *fakesnyk.Server) string {
+	if server != nil {
+		return "fake-role"
+	}
+
+	roleId := os.Getenv("SNYK_API_ROLE_ID")
+
+	if roleId == "" {
+		t.Skip("env variable SNYK_API_ROLE_ID required for service account acceptance tests")
+	}
+
+	return roleId
+}
+
+func testAccServiceAccountInGroup(name string, roleId string, groupId string) string {
+	return fmt.Sprintf(`
+	resource "snyk_service_account" "sa_test" {
+		group_id = "%s"
+		name     = "%s"
+		role_id  = "%s"
+		rotation_trigger = {
+			version = "1"
+		}
+	}`, groupId, name, roleId)
+}
+
+func testAccServiceAccount(name string, roleId string, rotation string) string {
+	return fmt.Sprintf(`
+	resource "snyk_service_account" "sa_test" {
+		name    = "%s"
+		role_id = "%s"
+		rotation_trigger = {
+			version = "%s"
+		}
+	}`, name, roleId, rotation)
+}
+
+func testAccCheckServiceAccountDestroy(s *terraform.State) error {
+	so := testAccOptions()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "snyk_service_account" {
+			continue
+		}
+
+		_, err := api.GetServiceAccount(context.Background(), so, testAccServiceAccountScope(rs), rs.Primary.ID)
+
+		if err == nil {
+			return fmt.Errorf("service account %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckServiceAccountExists(n string, sa *api.ServiceAccount) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		res, err := api.GetServiceAccount(context.Background(), testAccOptions(), testAccServiceAccountScope(rs), rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		*sa = *res
+
+		return nil
+	}
+}
+
+func testAccServiceAccountScope(rs *terraform.ResourceState) api.ServiceAccountScope {
+	return api.ServiceAccountScope{
+		GroupId: rs.Primary.Attributes["group_id"],
+		OrgId:   rs.Primary.Attributes["organization"],
+	}
+}
+
+func TestServiceAccountRotationDiff(t *testing.T) {
+	so := api.SnykOptions{GroupId: "provider-group"}
+
+	r := resourceServiceAccount()
+
+	config := func(authType string, trigger string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":             "sa",
+			"role_id":          "role",
+			"auth_type":        authType,
+			"rotation_trigger": map[string]interface{}{"a": trigger},
+		}
+	}
+
+	state := func(authType string, trigger string) *terraform.InstanceState {
+		raw, err := json.Marshal(config(authType, trigger))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rawConfig, err := ctyjson.Unmarshal(raw, r.CoreConfigSchema().ImpliedType())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &terraform.InstanceState{
+			ID:        "sa-id",
+			RawConfig: rawConfig,
+			Attributes: map[string]string{
+				"id":                 "sa-id",
+				"group_id":           "provider-group",
+				"name":               "sa",
+				"role_id":            "role",
+				"auth_type":          authType,
+				"rotation_trigger.%": "1",
+				"rotation_trigger.a": "1",
+				"client_id":          "client",
+				"token":              "secret",
+			},
+		}
+	}
+
+	cases := []struct {
+		authType    string
+		trigger     string
+		newComputed bool
+		replace     bool
+	}{
+		{"oauth_client_secret", "2", true, false},
+		{"oauth_client_secret", "1", false, false},
+		{"api_key", "2", true, true},
+	}
+
+	for _, c := range cases {
+		diff, err := r.Diff(context.Background(), state(c.authType, c.trigger), terraform.NewResourceConfigRaw(config(c.authType, c.trigger)), so)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, attr := range []string{"token", "client_id"} {
+			if computed := diff != nil && diff.Attributes[attr] != nil && diff.Attributes[attr].NewComputed; computed != c.newComputed {
+				t.Errorf("%s with trigger %s: expected %s unknown = %t, got %t", c.authType, c.trigger, attr, c.newComputed, computed)
+			}
+		}
+
+		if replace := diff != nil && diff.RequiresNew(); replace != c.replace {
+			t.Errorf("%s with trigger %s: expected replacement = %t, got %t", c.authType, c.trigger, c.replace, replace)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package snyk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func resourceTarget() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTargetCreate,
+		ReadContext:   resourceTargetRead,
+		DeleteContext: resourceTargetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTargetImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"integration_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"branch": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_private": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Targets can't be created directly - importing a repository through an integration creates the target
+// along with its projects, which is then looked up by name. Large repositories can take a while to import,
+// so the create timeout defaults to 30 minutes.
+func resourceTargetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := m.(api.SnykOptions)
+
+	orgId := d.Get("organization").(string)
+	integrationId := d.Get("integration_id").(string)
+	target := api.TargetImport{
+		Owner:  d.Get("owner").(string),
+		Name:   d.Get("name").(string),
+		Branch: d.Get("branch").(string),
+	}
+
+	jobId, err := api.ImportTarget(ctx, so, orgId, integrationId, target)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	wait := retry.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     []string{"complete"},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 5 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			job, err := api.GetImportJob(ctx, so, orgId, integrationId, jobId)
+
+			if err != nil {
+				return nil, "", err
+			}
+
+			if job.Status == "failed" {
+				return nil, "", fmt.Errorf("%w: %s", api.ErrImportFailed, jobId)
+			}
+
+			return job, job.Status, nil
+		},
+	}
+
+	_, err = wait.WaitForStateContext(ctx)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	displayName := fmt.Sprintf("%s/%s", target.Owner, target.Name)
+
+	targets, err := api.ListTargets(ctx, so, orgId, displayName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, t := range targets {
+		if t.IntegrationId == integrationId {
+			d.SetId(t.Id)
+			return resourceTargetRead(ctx, d, m)
+		}
+	}
+
+	return diag.Errorf("import of %s completed, but no target with that name was found", displayName)
+}
+
+func resourceTargetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+	orgId := d.Get("organization").(string)
+
+	target, err := api.GetTarget(ctx, so, orgId, d.Id())
+
+	if errors.Is(err, api.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// integration_id forces a replacement, which deletes the target's projects, so a response without the
+	// relationship keeps the one in state
+	if target.IntegrationId != "" {
+		d.Set("integration_id", target.IntegrationId)
+	}
+
+	d.Set("display_name", target.DisplayName)
+	d.Set("url", target.Url)
+	d.Set("is_private", target.IsPrivate)
+	d.Set("created", target.Created.String())
+
+	// repository targets are named "<owner>/<name>"
+	if parts := strings.SplitN(target.DisplayName, "/", 2); len(parts) == 2 {
+		d.Set("owner", parts[0])
+		d.Set("name", parts[1])
+	}
+
+	return diags
+}
+
+// Deleting the target also deletes every project imported from it.
+func resourceTargetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+	orgId := d.Get("organization").(string)
+
+	err := api.DeleteTarget(ctx, so, orgId, d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// Targets are imported as "<organization id>/<target id>".
+func resourceTargetImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <organization id>/<target id>", d.Id())
+	}
+
+	d.Set("organization", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
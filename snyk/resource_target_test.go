@@ -0,0 +1,102 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func TestAccTarget(t *testing.T) {
+	orgId := os.Getenv("SNYK_TARGET_ORG")
+	integrationId := os.Getenv("SNYK_TARGET_INTEGRATION")
+	owner := os.Getenv("SNYK_TARGET_OWNER")
+	name := os.Getenv("SNYK_TARGET_NAME")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if orgId == "" || integrationId == "" || owner == "" || name == "" {
+				t.Skip("env variables SNYK_TARGET_ORG, SNYK_TARGET_INTEGRATION, SNYK_TARGET_OWNER and SNYK_TARGET_NAME required for target acceptance tests")
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTarget(orgId, integrationId, owner, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_target.target_test", "display_name", fmt.Sprintf("%s/%s", owner, name)),
+					resource.TestCheckResourceAttr("data.snyk_targets.targets_test", "targets.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTarget(orgId string, integrationId string, owner string, name string) string {
+	return fmt.Sprintf(`
+	resource "snyk_target" "target_test" {
+		organization   = "%s"
+		integration_id = "%s"
+		owner          = "%s"
+		name           = "%s"
+	}
+
+	data "snyk_targets" "targets_test" {
+		organization = snyk_target.target_test.organization
+		display_name = snyk_target.target_test.display_name
+	}
+	`, orgId, integrationId, owner, name)
+}
+
+func testAccCheckTargetDestroy(s *terraform.State) error {
+	so := testAccOptions()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "snyk_target" {
+			continue
+		}
+
+		_, err := api.GetTarget(context.Background(), so, rs.Primary.Attributes["organization"], rs.Primary.ID)
+
+		if err == nil {
+			return fmt.Errorf("target %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func TestTargetReadWithoutIntegration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data": {"id": "target-id", "type": "target", "attributes": {"display_name": "owner/repo"}}}`)
+	}))
+	defer server.Close()
+
+	so := api.SnykOptions{ApiKey: "key", Endpoint: server.URL}
+
+	d := schema.TestResourceDataRaw(t, resourceTarget().Schema, map[string]interface{}{
+		"organization":   "org-id",
+		"integration_id": "integration-id",
+		"owner":          "owner",
+		"name":           "repo",
+	})
+	d.SetId("target-id")
+
+	if diags := resourceTargetRead(context.Background(), d, so); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if id := d.Get("integration_id").(string); id != "integration-id" {
+		t.Errorf("expected integration_id to be kept when the target has no integration relationship, got %q", id)
+	}
+}
@@ -0,0 +1,150 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func resourceWebhook() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWebhookCreate,
+		ReadContext:   resourceWebhookRead,
+		UpdateContext: resourceWebhookUpdate,
+		DeleteContext: resourceWebhookDelete,
+		CustomizeDiff: resourceWebhookCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceWebhookImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+			},
+			// replaced by CustomizeDiff when it changes, other than to adopt the secret of an imported webhook
+			"secret": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	so := m.(api.SnykOptions)
+
+	orgId := d.Get("organization").(string)
+	url := d.Get("url").(string)
+	secret := d.Get("secret").(string)
+
+	hook, err := api.CreateWebhook(ctx, so, orgId, url, secret)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// don't leave an unreachable webhook behind, as it would never be recorded in state
+	err = api.PingWebhook(ctx, so, orgId, hook.Id)
+
+	if err != nil {
+		if delErr := api.DeleteWebhook(ctx, so, orgId, hook.Id); delErr != nil {
+			return diag.Errorf("webhook %s failed verification (%s) and could not be removed: %s", hook.Id, err, delErr)
+		}
+
+		return diag.Errorf("unable to verify webhook %s is reachable: %s", url, err)
+	}
+
+	d.SetId(hook.Id)
+
+	return resourceWebhookRead(ctx, d, m)
+}
+
+func resourceWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+	orgId := d.Get("organization").(string)
+
+	hooks, err := api.ListWebhooks(ctx, so, orgId)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, hook := range hooks {
+		if hook.Id == d.Id() {
+			d.Set("url", hook.Url)
+			return diags
+		}
+	}
+
+	// webhook was removed outside of Terraform
+	d.SetId("")
+
+	return diags
+}
+
+// Only an imported webhook is updated, to record its secret in state. Snyk has nothing to update.
+func resourceWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceWebhookRead(ctx, d, m)
+}
+
+func resourceWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := m.(api.SnykOptions)
+	orgId := d.Get("organization").(string)
+
+	err := api.DeleteWebhook(ctx, so, orgId, d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// Webhooks can't be modified, so a new secret replaces the webhook. An imported webhook has no secret in state
+// as Snyk never returns it, and takes the configured one in place instead of being replaced.
+func resourceWebhookCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if old, _ := d.GetChange("secret"); d.Id() != "" && d.HasChange("secret") && old.(string) != "" {
+		return d.ForceNew("secret")
+	}
+
+	return nil
+}
+
+// Webhooks are imported as "<organization id>/<webhook id>". The secret cannot be read back from Snyk.
+func resourceWebhookImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <organization id>/<webhook id>", d.Id())
+	}
+
+	d.Set("organization", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
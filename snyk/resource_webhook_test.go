@@ -0,0 +1,172 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func TestAccWebhook(t *testing.T) {
+	server := testAccFakeServer(t)
+	rOrgName := acctest.RandomWithPrefix(testAccPrefix)
+
+	// the real API pings the webhook on creation, so it needs a reachable URL
+	url := "https://hooks.example.com/snyk"
+	if server == nil {
+		url = os.Getenv("SNYK_WEBHOOK_URL")
+
+		if url == "" {
+			t.Skip("env variable SNYK_WEBHOOK_URL required for webhook acceptance tests")
+		}
+	}
+
+	var id string
+
+	testAccRun(t, server, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebhook(rOrgName, url, "test_secret"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebhookExists("snyk_webhook.webhook_test"),
+					resource.TestCheckResourceAttr("snyk_webhook.webhook_test", "url", url),
+					testAccCaptureId("snyk_webhook.webhook_test", &id),
+				),
+			},
+			{
+				ResourceName:            "snyk_webhook.webhook_test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccWebhookImportId("snyk_webhook.webhook_test"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+			{
+				Config: testAccWebhook(rOrgName, url, "rotated_secret"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snyk_webhook.webhook_test", "secret", "rotated_secret"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["snyk_webhook.webhook_test"].Primary.ID == id {
+							return fmt.Errorf("expected webhook %s to be replaced with the new secret", id)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// An imported webhook has no secret in state, and takes the configured one without being replaced.
+func TestWebhookSecretDiff(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"organization": "org-id",
+		"url":          "https://hooks.example.com/snyk",
+		"secret":       "test_secret",
+	})
+
+	cases := map[string]struct {
+		secret  string
+		replace bool
+	}{
+		"imported":  {"", false},
+		"unchanged": {"test_secret", false},
+		"rotated":   {"old_secret", true},
+	}
+
+	for name, c := range cases {
+		state := &terraform.InstanceState{
+			ID: "hook-id",
+			Attributes: map[string]string{
+				"id":           "hook-id",
+				"organization": "org-id",
+				"url":          "https://hooks.example.com/snyk",
+				"secret":       c.secret,
+			},
+		}
+
+		diff, err := resourceWebhook().Diff(context.Background(), state, config, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if replace := diff != nil && diff.RequiresNew(); replace != c.replace {
+			t.Errorf("%s: expected replacement = %t, got %t", name, c.replace, replace)
+		}
+
+		if c.secret != "test_secret" && (diff == nil || diff.Attributes["secret"] == nil || diff.Attributes["secret"].New != "test_secret") {
+			t.Errorf("%s: expected the configured secret to be planned, got: %#v", name, diff)
+		}
+	}
+}
+
+func testAccCaptureId(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		*id = rs.Primary.ID
+
+		return nil
+	}
+}
+
+func testAccWebhook(name string, url string, secret string) string {
+	return fmt.Sprintf(`
+	resource "snyk_organization" "webhook_test_org" {
+		name = "%s"
+		deletion_protection = false
+	}
+
+	resource "snyk_webhook" "webhook_test" {
+		organization = snyk_organization.webhook_test_org.id
+		url          = "%s"
+		secret       = "%s"
+	}
+	`, name, url, secret)
+}
+
+func testAccWebhookImportId(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["organization"], rs.Primary.ID), nil
+	}
+}
+
+func testAccCheckWebhookExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		so := testAccOptions()
+
+		hooks, err := api.ListWebhooks(context.Background(), so, rs.Primary.Attributes["organization"])
+
+		if err != nil {
+			return err
+		}
+
+		for _, hook := range hooks {
+			if hook.Id == rs.Primary.ID {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("webhook %s not found", rs.Primary.ID)
+	}
+}
@@ -0,0 +1,155 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
+)
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("snyk_organization", &resource.Sweeper{
+		Name:         "snyk_organization",
+		F:            sweepOrganizations,
+		Dependencies: []string{"snyk_integration"},
+	})
+
+	resource.AddTestSweepers("snyk_integration", &resource.Sweeper{
+		Name: "snyk_integration",
+		F:    sweepIntegrations,
+	})
+}
+
+// sweeperOptions configures the API client from the same environment as the provider. Snyk has no regions,
+// so the region passed to sweepers is ignored.
+func sweeperOptions() (api.SnykOptions, error) {
+	so, err := testAccEnvOptions()
+
+	if err != nil {
+		return so, fmt.Errorf("unable to sweep: %w", err)
+	}
+
+	return so, nil
+}
+
+// sweptOrganizations returns the organizations created by acceptance tests.
+func sweptOrganizations(ctx context.Context, so api.SnykOptions) ([]api.Organization, error) {
+	orgs, err := api.ListOrganizations(ctx, so)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var swept []api.Organization
+	for _, org := range orgs {
+		if strings.HasPrefix(org.Name, testAccPrefix+"-") {
+			swept = append(swept, org)
+		}
+	}
+
+	return swept, nil
+}
+
+func sweepOrganizations(region string) error {
+	ctx := context.Background()
+	so, err := sweeperOptions()
+
+	if err != nil {
+		return err
+	}
+
+	orgs, err := sweptOrganizations(ctx, so)
+
+	if err != nil {
+		return err
+	}
+
+	for _, org := range orgs {
+		log.Printf("[INFO] deleting organization %s (%s)", org.Name, org.Id)
+
+		if err := api.DeleteOrganization(ctx, so, org.Id); err != nil {
+			return fmt.Errorf("failed to delete organization %s: %w", org.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func sweepIntegrations(region string) error {
+	ctx := context.Background()
+	so, err := sweeperOptions()
+
+	if err != nil {
+		return err
+	}
+
+	orgs, err := sweptOrganizations(ctx, so)
+
+	if err != nil {
+		return err
+	}
+
+	for _, org := range orgs {
+		integrations, err := api.ListIntegrations(ctx, so, org.Id)
+
+		if err != nil {
+			return err
+		}
+
+		for intType := range integrations {
+			log.Printf("[INFO] deleting %s integration of organization %s", intType, org.Name)
+
+			if err := api.DeleteIntegration(ctx, so, org.Id, intType); err != nil {
+				return fmt.Errorf("failed to delete %s integration of %s: %w", intType, org.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestSweepers(t *testing.T) {
+	server := fakesnyk.NewServer()
+	defer server.Close()
+
+	t.Setenv("SNYK_API_ENDPOINT", server.URL)
+	t.Setenv("SNYK_API_GROUP", fakesnyk.GroupId)
+	t.Setenv("SNYK_API_KEY", fakesnyk.ApiKey)
+
+	ctx := context.Background()
+	so, _ := sweeperOptions()
+
+	leaked, _ := api.CreateOrganization(ctx, so, testAccPrefix+"-leaked")
+	kept, _ := api.CreateOrganization(ctx, so, "Platform")
+	api.CreateIntegration(ctx, so, leaked.Id, "github", api.IntegrationCredentials{Token: "token"})
+	api.CreateIntegration(ctx, so, kept.Id, "github", api.IntegrationCredentials{Token: "token"})
+
+	if err := sweepIntegrations(""); err != nil {
+		t.Fatal(err)
+	}
+
+	if exists, _ := api.IntegrationExists(ctx, so, leaked.Id, "github"); exists {
+		t.Error("expected the test organization's integration to be swept")
+	}
+
+	if exists, _ := api.IntegrationExists(ctx, so, kept.Id, "github"); !exists {
+		t.Error("expected other organizations' integrations to be kept")
+	}
+
+	if err := sweepOrganizations(""); err != nil {
+		t.Fatal(err)
+	}
+
+	if server.Org(leaked.Id) != nil || server.Org(kept.Id) == nil {
+		t.Error("expected only the test organization to be swept")
+	}
+}
@@ -1,25 +1,145 @@
 package snyk
 
 import (
+	"context"
+	"encoding/json"
 	"os"
+	"os/exec"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lendi-au/terraform-provider-snyk/internal/fakesnyk"
+	"github.com/lendi-au/terraform-provider-snyk/snyk/api"
 )
 
-var testAccProviders = map[string]*schema.Provider{
-	"snyk": Provider("test")(),
+// testAccPrefix starts the name of everything acceptance tests create, so that sweepers can clean up
+// whatever failed runs leave behind.
+const testAccPrefix = "tf-acc-test"
+
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"snyk": func() (tfprotov6.ProviderServer, error) {
+		server, err := ProviderServer(context.Background(), "test")
+
+		if err != nil {
+			return nil, err
+		}
+
+		return server(), nil
+	},
+}
+
+// testAccOptions configures an API client from the environment, as the provider is, for checking results
+// against the API. It has no organization cache, so that checks see changes the provider has just made.
+func testAccOptions() api.SnykOptions {
+	so, _ := testAccEnvOptions()
+	return so
+}
+
+func testAccEnvOptions() (api.SnykOptions, error) {
+	var config providerConfig
+	err := config.resolve()
+
+	so := api.SnykOptions{
+		GroupId:   config.GroupId,
+		ApiKey:    config.ApiKey,
+		UserAgent: "terraform-provider-snyk/test",
+		Endpoint:  config.Endpoint,
+	}
+
+	if config.AuthMode == authModeOAuth {
+		so.Tokens = api.NewTokenSource(so, config.ClientId, config.ClientSecret)
+	}
+
+	return so, err
 }
 
+// testAccPreCheck requires a group, and either an API key or client credentials.
 func testAccPreCheck(t *testing.T) {
-	requiredEnvVars := []string{
-		"SNYK_API_GROUP",
-		"SNYK_API_KEY",
+	if os.Getenv("SNYK_API_GROUP") == "" {
+		t.Fatal("env variable SNYK_API_GROUP required for acceptance tests")
+	}
+
+	if !testAccRealCredentials() {
+		t.Fatal("env variable SNYK_API_KEY, or SNYK_CLIENT_ID and SNYK_CLIENT_SECRET, required for acceptance tests")
+	}
+}
+
+func testAccRealCredentials() bool {
+	return os.Getenv("SNYK_API_KEY") != "" || (os.Getenv("SNYK_CLIENT_ID") != "" && os.Getenv("SNYK_CLIENT_SECRET") != "")
+}
+
+// testAccFakeSnyk runs a test case against the real Snyk API when credentials are configured, and otherwise
+// against an in-process fake, so that the case also runs offline as part of go test. Only resources the
+// fake implements can be tested this way.
+func testAccFakeSnyk(t *testing.T, tc resource.TestCase) {
+	testAccRun(t, testAccFakeServer(t), tc)
+}
+
+// testAccFakeServer starts the fake Snyk API and points the provider at it, unless credentials for the real
+// API are configured, in which case it returns nil.
+func testAccFakeServer(t *testing.T) *fakesnyk.Server {
+	if testAccRealCredentials() {
+		return nil
 	}
 
-	for _, env := range requiredEnvVars {
-		if v := os.Getenv(env); v == "" {
-			t.Fatalf("env variable %s required for acceptance tests", env)
+	// without TF_ACC, don't let the test framework download terraform
+	if os.Getenv("TF_ACC") == "" && os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("terraform binary not found, set TF_ACC_TERRAFORM_PATH or TF_ACC to run against the fake Snyk API")
 		}
 	}
+
+	server := fakesnyk.NewServer()
+	t.Cleanup(server.Close)
+
+	t.Setenv("SNYK_API_ENDPOINT", server.URL)
+	t.Setenv("SNYK_API_GROUP", fakesnyk.GroupId)
+	t.Setenv("SNYK_API_KEY", fakesnyk.ApiKey)
+
+	return server
+}
+
+// testAccRun runs a test case against the fake Snyk API started by testAccFakeServer, or the real API when
+// there's no fake.
+func testAccRun(t *testing.T, server *fakesnyk.Server, tc resource.TestCase) {
+	if server == nil {
+		tc.PreCheck = func() { testAccPreCheck(t) }
+		resource.Test(t, tc)
+		return
+	}
+
+	resource.UnitTest(t, tc)
+}
+
+// testAccSkipBeforeTerraform skips tests of features older versions of Terraform don't support. It can only
+// check a terraform binary that's already installed; one the test framework downloads is always the latest.
+func testAccSkipBeforeTerraform(t *testing.T, minimum string) {
+	path := os.Getenv("TF_ACC_TERRAFORM_PATH")
+
+	if path == "" {
+		var err error
+		if path, err = exec.LookPath("terraform"); err != nil {
+			return
+		}
+	}
+
+	out, err := exec.Command(path, "version", "-json").Output()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v struct {
+		Version string `json:"terraform_version"`
+	}
+
+	if err := json.Unmarshal(out, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	if version.Must(version.NewVersion(v.Version)).Core().LessThan(version.Must(version.NewVersion(minimum))) {
+		t.Skipf("requires terraform %s or later, got %s", minimum, v.Version)
+	}
 }
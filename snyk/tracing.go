@@ -0,0 +1,160 @@
+package snyk
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/lendi-au/terraform-provider-snyk/snyk"
+
+// InitTracing exports spans over OTLP when the standard OTEL_* environment variables ask for it, and
+// otherwise leaves the global no-op tracer in place. The returned function flushes any pending spans.
+func InitTracing(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	if !tracingConfigured() {
+		return noop, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+
+	if err != nil {
+		return noop, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName("terraform-provider-snyk"),
+			semconv.ServiceVersion(version),
+		),
+		resource.WithFromEnv(),
+	)
+
+	if err != nil {
+		return noop, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// tracingConfigured follows the OTel SDK environment conventions: nothing is exported unless an OTLP
+// endpoint or exporter is given, and OTEL_SDK_DISABLED or OTEL_TRACES_EXPORTER=none turn it off.
+func tracingConfigured() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+
+	switch os.Getenv("OTEL_TRACES_EXPORTER") {
+	case "none":
+		return false
+	case "otlp":
+		return true
+	}
+
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// traceResource wraps each CRUD function of a resource or data source in a span. Data sources are named
+// with a "data." prefix to tell them apart from resources of the same name.
+func traceResource(typeName string, r *schema.Resource) *schema.Resource {
+	orgId := func(d *schema.ResourceData) string {
+		if _, ok := r.Schema["organization"]; ok {
+			return d.Get("organization").(string)
+		}
+		return ""
+	}
+
+	if r.CreateContext != nil {
+		r.CreateContext = schema.CreateContextFunc(traced(typeName, "create", orgId, r.CreateContext))
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = schema.ReadContextFunc(traced(typeName, "read", orgId, r.ReadContext))
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = schema.UpdateContextFunc(traced(typeName, "update", orgId, r.UpdateContext))
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = schema.DeleteContextFunc(traced(typeName, "delete", orgId, r.DeleteContext))
+	}
+
+	return r
+}
+
+type crudFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+func traced(typeName string, operation string, orgId func(*schema.ResourceData) string, fn crudFunc) crudFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		ctx, span := startSpan(ctx, typeName, operation)
+
+		diags := fn(ctx, d, m)
+
+		var summary string
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Error {
+				summary = diagnostic.Summary
+				break
+			}
+		}
+
+		// read after the call, as the organization ID is only known once the resource is created
+		endSpan(span, orgId(d), diags.HasError(), summary)
+
+		return diags
+	}
+}
+
+// startSpan starts the span for one operation on a resource or data source. Framework resources call it
+// themselves, as there's no function to wrap.
+func startSpan(ctx context.Context, typeName string, operation string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, typeName+"."+operation, trace.WithAttributes(
+		attribute.String("snyk.resource_type", typeName),
+		attribute.String("snyk.operation", operation),
+	))
+}
+
+func endSpan(span trace.Span, orgId string, failed bool, summary string) {
+	defer span.End()
+
+	if orgId != "" {
+		span.SetAttributes(attribute.String("snyk.org_id", orgId))
+	}
+
+	if failed {
+		span.SetStatus(codes.Error, summary)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+}
+
+// endFrameworkSpan ends a span started by a framework resource or data source.
+func endFrameworkSpan(span trace.Span, orgId string, diags fwdiag.Diagnostics) {
+	var summary string
+	if errs := diags.Errors(); len(errs) > 0 {
+		summary = errs[0].Summary()
+	}
+
+	endSpan(span, orgId, diags.HasError(), summary)
+}
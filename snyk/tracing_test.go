@@ -0,0 +1,86 @@
+package snyk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceResource(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	defer provider.Shutdown(context.Background())
+
+	r := traceResource("snyk_test", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"organization": {Type: schema.TypeString, Required: true},
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return nil
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return diag.Errorf("could not delete")
+		},
+	})
+
+	if r.CreateContext != nil || r.UpdateContext != nil {
+		t.Fatal("expected missing functions to stay unset")
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"organization": "abc"})
+
+	r.ReadContext(context.Background(), d, nil)
+	r.DeleteContext(context.Background(), d, nil)
+
+	spans := exporter.GetSpans()
+
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	if spans[0].Name != "snyk_test.read" || spans[0].Status.Code != codes.Ok {
+		t.Errorf("unexpected read span: %s %v", spans[0].Name, spans[0].Status)
+	}
+
+	if spans[1].Name != "snyk_test.delete" || spans[1].Status.Code != codes.Error || spans[1].Status.Description != "could not delete" {
+		t.Errorf("unexpected delete span: %s %v", spans[1].Name, spans[1].Status)
+	}
+
+	attributes := map[string]string{}
+	for _, kv := range spans[0].Attributes {
+		attributes[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	if attributes["snyk.org_id"] != "abc" || attributes["snyk.resource_type"] != "snyk_test" {
+		t.Errorf("unexpected attributes: %v", attributes)
+	}
+}
+
+func TestTracingConfigured(t *testing.T) {
+	for _, key := range []string{"OTEL_SDK_DISABLED", "OTEL_TRACES_EXPORTER", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"} {
+		t.Setenv(key, "")
+	}
+
+	if tracingConfigured() {
+		t.Error("expected tracing to be off without any configuration")
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+
+	if !tracingConfigured() {
+		t.Error("expected tracing to be on with an OTLP endpoint")
+	}
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+
+	if tracingConfigured() {
+		t.Error("expected OTEL_TRACES_EXPORTER=none to turn tracing off")
+	}
+}